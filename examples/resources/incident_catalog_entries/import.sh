@@ -0,0 +1,3 @@
+# Import the entries of a catalog type using its ID, type name or name.
+terraform import incident_catalog_entries.services 01GW2G3V0S59R238FAHPDS1R66
+terraform import incident_catalog_entries.services 'Custom["Service"]'
@@ -0,0 +1,2 @@
+# Import the entries of several catalog types using a comma separated list of their IDs.
+terraform import incident_catalog_entries_set.services_and_teams 01GW2G3V0S59R238FAHPDS1R66,01GW2G3V0S59R238FAHPDS1R67
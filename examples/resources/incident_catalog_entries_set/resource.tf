@@ -0,0 +1,46 @@
+# Teams and the services they own, where each service references its owning
+# team. Both types are managed together, so services can reference teams by
+# external ID even when the teams are created in the same apply.
+resource "incident_catalog_type" "team" {
+  name        = "Team"
+  description = "All teams at Example Org"
+}
+
+resource "incident_catalog_type" "service" {
+  name        = "Service"
+  description = "All services that we run at Example Org"
+}
+
+resource "incident_catalog_type_attribute" "service_owner" {
+  catalog_type_id = incident_catalog_type.service.id
+
+  name = "Owner"
+  type = incident_catalog_type.team.type_name
+}
+
+resource "incident_catalog_entries_set" "services_and_teams" {
+  catalog_types = {
+    (incident_catalog_type.team.id) = {
+      entries = {
+        "payments" = {
+          name             = "Payments"
+          attribute_values = {}
+        }
+      }
+    }
+
+    (incident_catalog_type.service.id) = {
+      entries = {
+        "payments-api" = {
+          name = "Payments API"
+
+          attribute_values = {
+            (incident_catalog_type_attribute.service_owner.id) = {
+              value = "payments" # the external ID of the team
+            }
+          }
+        }
+      }
+    }
+  }
+}
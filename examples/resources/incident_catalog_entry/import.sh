@@ -0,0 +1,2 @@
+# Import a catalog entry using its ID.
+terraform import incident_catalog_entry.service_tier 01HQ4ZJ7R8XKSGZ2W4N6B3D5EA
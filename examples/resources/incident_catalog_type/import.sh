@@ -0,0 +1,2 @@
+# Import a catalog type using its ID.
+terraform import incident_catalog_type.service_tier 01HQ4ZHDYQ3NBV7P9T2KMC8R1F
@@ -0,0 +1,3 @@
+# Import a catalog type attribute using the ID of its catalog type and its own ID,
+# separated by a colon.
+terraform import incident_catalog_type_attribute.service_description 01HQ4ZHDYQ3NBV7P9T2KMC8R1F:01HQ4ZKB6M2XWE5Y8S0TQH7V3J
@@ -0,0 +1,2 @@
+# Import a custom field using its ID.
+terraform import incident_custom_field.affected_teams 01HQ4ZM1F9C6TR3D8W5XAJ2N0K
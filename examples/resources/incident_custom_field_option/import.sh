@@ -0,0 +1,2 @@
+# Import a custom field option using its ID.
+terraform import incident_custom_field_option.teams 01HQ4ZMV4P7B2HS9K6GQX1E8YD
@@ -0,0 +1,2 @@
+# Import an incident role using its ID.
+terraform import incident_incident_role.comms 01HQ4ZNJ8T5W0DK3R7MFV2C9BA
@@ -0,0 +1,3 @@
+# Import a schedule using its ID or name. Names must be unique to be used for import.
+terraform import incident_schedule.primary_on_call 01HPFH8T92MPGSQS5C1SPAF4V0
+terraform import incident_schedule.primary_on_call 'Primary On-call'
@@ -29,9 +29,10 @@ resource "incident_schedule" "primary_on_call" {
         # Expects an RFC3339 formatted string
         handover_start_at = "2024-05-01T12:54:13Z"
 
-        # Reference the data sources for the users
+        # Reference the data sources for the users, or give users by email
         users = [
           data.incident_user.martha.id,
+          "rory@incident.io",
         ]
 
         # The number of concurrent users that can be on-call at the same time for a given
@@ -0,0 +1,2 @@
+# Import a severity using its ID.
+terraform import incident_severity.trivial 01HQ4ZP6H2N9XQ4B1T8JYW5E3S
@@ -0,0 +1,2 @@
+# Import an incident status using its ID.
+terraform import incident_status.clean_up 01HQ4ZPX0V3K8MC6F2RDN9T7GH
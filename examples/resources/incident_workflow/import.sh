@@ -0,0 +1,3 @@
+# Import a workflow using its ID. Once imported, the workflow will be marked as
+# managed by Terraform in the incident.io dashboard.
+terraform import incident_workflow.autoassign_incident_lead 01HQ4ZQK5S1EY7W3B9PCA6M2XN
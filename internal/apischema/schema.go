@@ -3,9 +3,13 @@ package apischema
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/getkin/kin-openapi/openapi2"
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 
 	_ "embed"
 )
@@ -51,3 +55,127 @@ func Property(definitionName, propertyName string) *openapi3.SchemaRef {
 func Docstring(definitionName, propertyName string) string {
 	return Property(definitionName, propertyName).Value.Description
 }
+
+// Enum returns the values a property is allowed to take, for properties that are an enum
+// or an array of one.
+func Enum(definitionName, propertyName string) []string {
+	property := Property(definitionName, propertyName).Value
+	if property.Type == "array" && property.Items != nil && property.Items.Value != nil {
+		property = property.Items.Value
+	}
+
+	values := property.Enum
+	if len(values) == 0 {
+		panic(fmt.Sprintf("property %s of definition %s is not an enum", propertyName, definitionName))
+	}
+
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		result = append(result, fmt.Sprint(value))
+	}
+
+	return result
+}
+
+// OneOf returns a validator that checks a string attribute is one of the values the API
+// allows for a property, so we catch invalid values when planning rather than when the
+// API rejects them during apply.
+func OneOf(definitionName, propertyName string) validator.String {
+	return stringvalidator.OneOf(Enum(definitionName, propertyName)...)
+}
+
+// Validate checks a value decoded from JSON against a definition, returning an error that
+// describes where it first fails to match.
+//
+// As in JSON schema, objects may have properties that the definition doesn't describe, so
+// values can use parts of the API that are newer than our copy of the schema.
+func Validate(definitionName string, value any) error {
+	return validate(Def(definitionName), value, "")
+}
+
+func validate(schemaRef *openapi3.SchemaRef, value any, at string) error {
+	// References to other definitions aren't resolved when we load the schema.
+	if schemaRef.Value == nil {
+		schemaRef = Def(strings.TrimPrefix(schemaRef.Ref, "#/definitions/"))
+	}
+	schema := schemaRef.Value
+
+	describe := func(message string, args ...any) error {
+		if at == "" {
+			return fmt.Errorf(message, args...)
+		}
+
+		return fmt.Errorf("%s: %s", at, fmt.Sprintf(message, args...))
+	}
+
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return describe("must be an object")
+		}
+
+		// Our schema marks some properties as required that it doesn't then define, which the
+		// API doesn't actually require, so we only check those it does define.
+		for _, name := range schema.Required {
+			if _, defined := schema.Properties[name]; !defined {
+				continue
+			}
+			if _, ok := object[name]; !ok {
+				return describe("%s is required", name)
+			}
+		}
+
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			property, ok := schema.Properties[name]
+			if !ok || object[name] == nil {
+				continue
+			}
+			if err := validate(property, object[name], strings.TrimPrefix(at+"."+name, ".")); err != nil {
+				return err
+			}
+		}
+	case "array":
+		array, ok := value.([]any)
+		if !ok {
+			return describe("must be an array")
+		}
+
+		for idx, element := range array {
+			if err := validate(schema.Items, element, fmt.Sprintf("%s[%d]", at, idx)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return describe("must be a string")
+		}
+
+		if len(schema.Enum) > 0 {
+			for _, allowed := range schema.Enum {
+				if fmt.Sprint(allowed) == str {
+					return nil
+				}
+			}
+
+			return describe("must be one of %v, got %q", schema.Enum, str)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return describe("must be a boolean")
+		}
+	case "integer", "number":
+		if _, ok := value.(float64); !ok {
+			return describe("must be a number")
+		}
+	}
+
+	return nil
+}
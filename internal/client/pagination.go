@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+)
+
+// Page is a single page of results from one of the list endpoints, along with the cursor
+// to pass as the 'after' param to load the page that follows it.
+type Page[T any] struct {
+	Items []T
+	After *string
+}
+
+// Paginate loads every page from a cursor-paginated list endpoint, calling each with the
+// items of every page in turn. fetch loads the page after the given cursor, which is nil
+// for the first page.
+//
+// Pagination is cursor based, so we can't fetch pages concurrently. We can avoid asking
+// for an empty page at the end though, as the API tells us when there are no more results.
+func Paginate[T any](ctx context.Context, fetch func(ctx context.Context, after *string) (*Page[T], error), each func(items []T) error) error {
+	var after *string
+	for {
+		page, err := fetch(ctx, after)
+		if err != nil {
+			return err
+		}
+
+		if err := each(page.Items); err != nil {
+			return err
+		}
+
+		if len(page.Items) == 0 || page.After == nil {
+			return nil // end pagination
+		}
+
+		after = page.After
+	}
+}
+
+// ListAll loads every page from a cursor-paginated list endpoint, returning all the items
+// across them.
+func ListAll[T any](ctx context.Context, fetch func(ctx context.Context, after *string) (*Page[T], error)) ([]T, error) {
+	items := []T{}
+	err := Paginate(ctx, fetch, func(page []T) error {
+		items = append(items, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
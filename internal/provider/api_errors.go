@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// apiError is the envelope the incident.io API returns when a request fails, such as:
+//
+//	{
+//	  "type": "validation_error",
+//	  "status": 422,
+//	  "request_id": "...",
+//	  "errors": [{"code": "is_required", "message": "...", "source": {"field": "name"}}]
+//	}
+type apiError struct {
+	StatusCode int             `json:"status"`
+	Type       string          `json:"type"`
+	RequestID  string          `json:"request_id"`
+	Errors     []apiFieldError `json:"errors"`
+}
+
+type apiFieldError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Source  struct {
+		Field string `json:"field"`
+	} `json:"source"`
+}
+
+// newAPIError builds an error from the body of a failed response. If the body isn't an
+// error envelope we recognise, the error is the body as-is.
+func newAPIError(statusCode int, body []byte) error {
+	var result apiError
+	if err := json.Unmarshal(body, &result); err != nil || result.Type == "" {
+		return errors.New(string(body))
+	}
+	if result.StatusCode == 0 {
+		result.StatusCode = statusCode
+	}
+
+	return &result
+}
+
+func (e *apiError) Error() string {
+	messages := []string{}
+	for _, fieldErr := range e.Errors {
+		messages = append(messages, fieldErr.String())
+	}
+
+	msg := fmt.Sprintf("%s (status %d)", e.Type, e.StatusCode)
+	if len(messages) > 0 {
+		msg += ": " + strings.Join(messages, "; ")
+	}
+	msg += e.requestIDSuffix()
+
+	return msg
+}
+
+func (e apiFieldError) String() string {
+	if e.Source.Field == "" {
+		return e.Message
+	}
+
+	return fmt.Sprintf("%s: %s", e.Source.Field, e.Message)
+}
+
+// addAPIError reports an error from creating or updating a resource. Where the API told
+// us which field was invalid, the error is attached to that attribute, so Terraform can
+// point at the configuration that caused it. Everything else is reported as a Client
+// Error, in the same way as any other failed request.
+//
+// This only makes sense for resources whose attributes mirror their request payload:
+// schedules, for example, are sent to the API in a different shape to their schema.
+func addAPIError(diags *diag.Diagnostics, summary string, err error) {
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) || !apiErr.hasFieldErrors() {
+		diags.AddError("Client Error", fmt.Sprintf("%s, got error: %s", summary, err))
+		return
+	}
+
+	for _, fieldErr := range apiErr.Errors {
+		detail := fmt.Sprintf("%s, got error: %s%s", summary, fieldErr.Message, apiErr.requestIDSuffix())
+		if fieldErr.Source.Field == "" {
+			diags.AddError("Client Error", detail)
+			continue
+		}
+
+		diags.AddAttributeError(apiFieldPath(fieldErr.Source.Field), "Client Error", detail)
+	}
+}
+
+// requestIDSuffix returns the request ID to append to an error message, so anyone
+// reporting an error to incident.io can tell them which request failed.
+func (e *apiError) requestIDSuffix() string {
+	if e.RequestID == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(" [request_id=%s]", e.RequestID)
+}
+
+func (e *apiError) hasFieldErrors() bool {
+	for _, fieldErr := range e.Errors {
+		if fieldErr.Source.Field != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+var apiFieldPathSegment = regexp.MustCompile(`[^.\[\]]+`)
+
+// apiFieldPath converts a field from an API error, such as rotations[0].versions.1.users,
+// into the path of the attribute it refers to.
+func apiFieldPath(field string) path.Path {
+	var result path.Path
+	for idx, segment := range apiFieldPathSegment.FindAllString(field, -1) {
+		if position, err := strconv.Atoi(segment); err == nil && idx > 0 {
+			result = result.AtListIndex(position)
+		} else if idx == 0 {
+			result = path.Root(segment)
+		} else {
+			result = result.AtName(segment)
+		}
+	}
+
+	return result
+}
+
+// isNotFound returns true if err is the API telling us the resource doesn't exist.
+func isNotFound(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
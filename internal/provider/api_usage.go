@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// apiUsage counts the requests each resource makes to the API, broken down by resource
+// type and operation, so we can see how hard a run worked the API. This is useful when
+// tuning concurrency, or finding resources that refresh more often than they should.
+//
+// Terraform starts a new provider process for each stage of a command, such as validate,
+// plan and apply, so no single process sees a whole run. Instead, each operation logs
+// what it did as it finishes, alongside the totals for its resource type and operation
+// so far in this process, and the logs for the run can be read together.
+type apiUsage struct {
+	mu         sync.Mutex
+	operations map[apiUsageKey]*apiOperationUsage
+}
+
+type apiUsageKey struct {
+	resourceType string
+	operation    string
+}
+
+type apiOperationUsage struct {
+	calls       int
+	requests    int
+	retries     int
+	rateLimited int
+	errors      int
+	duration    time.Duration
+}
+
+func newAPIUsage() *apiUsage {
+	return &apiUsage{
+		operations: map[apiUsageKey]*apiOperationUsage{},
+	}
+}
+
+// track attributes the requests made using the returned context to a resource type and
+// operation, such as incident_schedule and Read. The returned function should be called
+// when the operation finishes, to log the requests it made.
+func (u *apiUsage) track(ctx context.Context, resourceType, operation string) (context.Context, func()) {
+	if u == nil {
+		return ctx, func() {}
+	}
+
+	started := time.Now()
+	tracker := &apiUsageTracker{}
+	ctx = context.WithValue(ctx, apiUsageTrackerKey{}, tracker)
+
+	return ctx, func() {
+		call := tracker.snapshot()
+		if call.requests == 0 {
+			return
+		}
+
+		total := u.add(apiUsageKey{resourceType: resourceType, operation: operation}, call)
+		tflog.Info(ctx, "API usage", map[string]interface{}{
+			"resource_type":    resourceType,
+			"operation":        operation,
+			"elapsed":          time.Since(started).Round(time.Millisecond).String(),
+			"requests":         call.requests,
+			"request_duration": call.duration.Round(time.Millisecond).String(),
+			"retries":          call.retries,
+			"rate_limited":     call.rateLimited,
+			"errors":           call.errors,
+			"total_calls":      total.calls,
+			"total_requests":   total.requests,
+			"total_retries":    total.retries,
+			"total_duration":   total.duration.Round(time.Millisecond).String(),
+		})
+	}
+}
+
+// add records the usage of a single call of an operation, returning the totals for that
+// operation so far.
+func (u *apiUsage) add(key apiUsageKey, call apiOperationUsage) apiOperationUsage {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	total, ok := u.operations[key]
+	if !ok {
+		total = &apiOperationUsage{}
+		u.operations[key] = total
+	}
+
+	total.calls++
+	total.requests += call.requests
+	total.retries += call.retries
+	total.rateLimited += call.rateLimited
+	total.errors += call.errors
+	total.duration += call.duration
+
+	return *total
+}
+
+type apiUsageTrackerKey struct{}
+
+// apiUsageTracker counts the requests made by a single call of an operation, which may
+// make requests concurrently.
+type apiUsageTracker struct {
+	mu    sync.Mutex
+	usage apiOperationUsage
+}
+
+// apiUsageTrackerFromContext returns the tracker for the operation a context belongs to,
+// or nil if it isn't being tracked.
+func apiUsageTrackerFromContext(ctx context.Context) *apiUsageTracker {
+	tracker, _ := ctx.Value(apiUsageTrackerKey{}).(*apiUsageTracker)
+	return tracker
+}
+
+func (t *apiUsageTracker) recordRequest(statusCode int, err error, duration time.Duration) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.usage.requests++
+	t.usage.duration += duration
+	if statusCode == http.StatusTooManyRequests {
+		t.usage.rateLimited++
+	}
+	if err != nil || statusCode >= 400 {
+		t.usage.errors++
+	}
+}
+
+func (t *apiUsageTracker) recordRetry() {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.usage.retries++
+}
+
+func (t *apiUsageTracker) snapshot() apiOperationUsage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.usage
+}
+
+// apiUsageTransport records every request made through it against the operation that
+// made it.
+type apiUsageTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *apiUsageTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	started := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+
+	var statusCode int
+	if resp != nil {
+		statusCode = resp.StatusCode
+	}
+	apiUsageTrackerFromContext(req.Context()).recordRequest(statusCode, err, time.Since(started))
+
+	return resp, err
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIUsageTrack(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/rate-limited" {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: &apiUsageTransport{transport: http.DefaultTransport}}
+	get := func(ctx context.Context, path string) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	usage := newAPIUsage()
+	for call := 0; call < 2; call++ {
+		ctx, done := usage.track(context.Background(), "incident_schedule", "Read")
+		get(ctx, "/ok")
+		get(ctx, "/rate-limited")
+		apiUsageTrackerFromContext(ctx).recordRetry()
+		done()
+	}
+
+	// Requests made outside of an operation aren't attributed to anything.
+	get(context.Background(), "/ok")
+
+	ctx, done := usage.track(context.Background(), "incident_schedule", "Update")
+	get(ctx, "/ok")
+	done()
+
+	expected := map[apiUsageKey]apiOperationUsage{
+		{resourceType: "incident_schedule", operation: "Read"}:   {calls: 2, requests: 4, retries: 2, rateLimited: 2, errors: 2},
+		{resourceType: "incident_schedule", operation: "Update"}: {calls: 1, requests: 1},
+	}
+	if len(usage.operations) != len(expected) {
+		t.Fatalf("expected usage for %d operations, got %d", len(expected), len(usage.operations))
+	}
+	for key, want := range expected {
+		got, ok := usage.operations[key]
+		if !ok {
+			t.Fatalf("expected usage for %s %s", key.resourceType, key.operation)
+		}
+		got.duration = 0
+		if *got != want {
+			t.Errorf("expected %s %s usage %+v, got %+v", key.resourceType, key.operation, want, *got)
+		}
+	}
+}
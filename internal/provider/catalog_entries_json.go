@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+// catalogEntryJSON is the shape of each entry in entries_json, keyed by external ID. It
+// mirrors the nested entries attribute so the two can be used interchangeably.
+type catalogEntryJSON struct {
+	Name            string                                      `json:"name"`
+	Aliases         []string                                    `json:"aliases"`
+	Rank            *int64                                      `json:"rank"`
+	AttributeValues map[string]catalogEntryAttributeBindingJSON `json:"attribute_values"`
+}
+
+type catalogEntryAttributeBindingJSON struct {
+	Value      *string   `json:"value"`
+	ArrayValue *[]string `json:"array_value"`
+}
+
+// parseCatalogEntriesJSON decodes the content of entries_json into the same model we'd
+// have received had the entries been provided using the entries attribute.
+//
+// The current entries are used to carry over the IDs of entries that already exist, in the
+// same way the entries attribute would when using the state for unknown IDs.
+func parseCatalogEntriesJSON(content string, current map[string]CatalogEntryModel) (map[string]CatalogEntryModel, error) {
+	decoder := json.NewDecoder(bytes.NewBufferString(content))
+	decoder.DisallowUnknownFields()
+
+	var document map[string]catalogEntryJSON
+	if err := decoder.Decode(&document); err != nil {
+		return nil, errors.Wrap(err, "decoding entries")
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected content after the entries object")
+	}
+	if document == nil {
+		return nil, fmt.Errorf("entries must be a JSON object of external ID to entry")
+	}
+
+	externalIDs := lo.Keys(document)
+	sort.Strings(externalIDs)
+
+	entries := map[string]CatalogEntryModel{}
+	for _, externalID := range externalIDs {
+		entry := document[externalID]
+		if entry.Name == "" {
+			return nil, fmt.Errorf("entry %q must have a name", externalID)
+		}
+
+		aliases := []attr.Value{}
+		for _, alias := range entry.Aliases {
+			aliases = append(aliases, types.StringValue(alias))
+		}
+
+		values := map[string]CatalogEntryAttributeBindingModel{}
+		for attributeKey, binding := range entry.AttributeValues {
+			if binding.Value != nil && binding.ArrayValue != nil {
+				return nil, fmt.Errorf("entry %q has both value and array_value set for attribute %q", externalID, attributeKey)
+			}
+
+			value := CatalogEntryAttributeBindingModel{
+				Value:      types.StringNull(),
+				ArrayValue: types.ListNull(types.StringType),
+			}
+			if binding.Value != nil {
+				value.Value = types.StringValue(*binding.Value)
+			}
+			if binding.ArrayValue != nil {
+				elements := []attr.Value{}
+				for _, element := range *binding.ArrayValue {
+					elements = append(elements, types.StringValue(element))
+				}
+				value.ArrayValue = types.ListValueMust(types.StringType, elements)
+			}
+
+			values[attributeKey] = value
+		}
+
+		id := types.StringUnknown()
+		if existing, ok := current[externalID]; ok && !existing.ID.IsNull() {
+			id = existing.ID
+		}
+
+		entries[externalID] = CatalogEntryModel{
+			ID:              id,
+			Name:            types.StringValue(entry.Name),
+			Aliases:         types.ListValueMust(types.StringType, aliases),
+			Rank:            types.Int64Value(lo.FromPtr(entry.Rank)),
+			AttributeValues: values,
+		}
+	}
+
+	return entries, nil
+}
+
+// rankedCatalogEntriesJSON returns the external IDs of the entries in entries_json that
+// set a rank, in order. Parsing the entries defaults rank to zero, so we can't tell from
+// the parsed model which entries set it. Invalid JSON is reported when parsing, so here
+// it's treated as having no ranks.
+func rankedCatalogEntriesJSON(content string) []string {
+	var document map[string]catalogEntryJSON
+	if err := json.Unmarshal([]byte(content), &document); err != nil {
+		return nil
+	}
+
+	externalIDs := []string{}
+	for externalID, entry := range document {
+		if entry.Rank != nil {
+			externalIDs = append(externalIDs, externalID)
+		}
+	}
+	sort.Strings(externalIDs)
+
+	return externalIDs
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/incident-io/terraform-provider-incident/internal/client"
+	"github.com/pkg/errors"
+)
+
+// catalogEntryReferences resolves the values of attributes whose type is another catalog
+// type into the IDs of the entries they refer to.
+//
+// This allows users to reference entries by their ID, external ID or any of their aliases,
+// rather than having to plumb entry IDs through their config. Catalog types and their
+// entries are loaded lazily and cached, so a single instance should be used for no longer
+// than a single terraform operation.
+type catalogEntryReferences struct {
+	r *IncidentCatalogEntriesResource
+
+	catalogTypes map[string]client.CatalogTypeV2 // type name => catalog type
+	entryIDs     map[string]map[string]string    // catalog type ID => reference => entry ID
+}
+
+func newCatalogEntryReferences(r *IncidentCatalogEntriesResource) *catalogEntryReferences {
+	return &catalogEntryReferences{
+		r:        r,
+		entryIDs: map[string]map[string]string{},
+	}
+}
+
+// catalogTypeFor returns the catalog type that an attribute's values reference, or nil if
+// the attribute is of a primitive type like String or Number.
+func (c *catalogEntryReferences) catalogTypeFor(ctx context.Context, attribute client.CatalogTypeAttributeV2) (*client.CatalogTypeV2, error) {
+	if c.catalogTypes == nil {
+		result, err := c.r.client.CatalogV2ListTypesWithResponse(ctx)
+		if err == nil && result.StatusCode() >= 400 {
+			err = newAPIError(result.StatusCode(), result.Body)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "listing catalog types")
+		}
+
+		c.catalogTypes = map[string]client.CatalogTypeV2{}
+		for _, catalogType := range result.JSON200.CatalogTypes {
+			c.catalogTypes[catalogType.TypeName] = catalogType
+		}
+	}
+
+	catalogType, ok := c.catalogTypes[attribute.Type]
+	if !ok {
+		return nil, nil
+	}
+
+	return &catalogType, nil
+}
+
+// resolve returns the ID of the entry in the catalog type that matches the given reference
+// by ID, external ID or alias. If nothing matches we return the reference unchanged, and
+// leave it to the API to decide whether it is valid.
+func (c *catalogEntryReferences) resolve(ctx context.Context, catalogTypeID, reference string) (string, error) {
+	entryIDs, ok := c.entryIDs[catalogTypeID]
+	if !ok {
+		_, entries, err := c.r.getEntries(ctx, catalogTypeID, catalogEntriesDefaultPageSize)
+		if err != nil {
+			return "", errors.Wrap(err, fmt.Sprintf("listing entries for catalog type id=%s", catalogTypeID))
+		}
+
+		// Apply these in reverse order of precedence, so an ID always wins over an external ID,
+		// which always wins over an alias.
+		entryIDs = map[string]string{}
+		for _, entry := range entries {
+			for _, alias := range entry.Aliases {
+				entryIDs[alias] = entry.Id
+			}
+		}
+		for _, entry := range entries {
+			if entry.ExternalId != nil {
+				entryIDs[*entry.ExternalId] = entry.Id
+			}
+		}
+		for _, entry := range entries {
+			entryIDs[entry.Id] = entry.Id
+		}
+
+		c.entryIDs[catalogTypeID] = entryIDs
+	}
+
+	entryID, ok := entryIDs[reference]
+	if !ok {
+		tflog.Debug(ctx, fmt.Sprintf("could not find an entry matching %q in catalog type id=%s, using it as-is", reference, catalogTypeID))
+		return reference, nil
+	}
+
+	return entryID, nil
+}
+
+// forget drops any entries we've cached for a catalog type, so that references are resolved
+// against its current entries after we've changed them.
+func (c *catalogEntryReferences) forget(catalogTypeID string) {
+	delete(c.entryIDs, catalogTypeID)
+}
+
+// equivalent returns true if the configured value for an attribute refers to the same
+// thing as the current value we received from the API.
+func (c *catalogEntryReferences) equivalent(ctx context.Context, attribute client.CatalogTypeAttributeV2, configured, current string) (bool, error) {
+	if catalogEntryValuesEquivalent(attribute, configured, current) {
+		return true, nil
+	}
+
+	catalogType, err := c.catalogTypeFor(ctx, attribute)
+	if err != nil {
+		return false, err
+	}
+	if catalogType == nil {
+		return false, nil
+	}
+
+	resolved, err := c.resolve(ctx, catalogType.Id, configured)
+	if err != nil {
+		return false, err
+	}
+
+	return resolved == current, nil
+}
@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/incident-io/terraform-provider-incident/internal/client"
+)
+
+// Catalog attribute types that have a canonical representation, which terraform can't
+// express as attribute values are always strings.
+const (
+	catalogAttributeTypeBool      = "Bool"
+	catalogAttributeTypeNumber    = "Number"
+	catalogAttributeTypeTimestamp = "Timestamp"
+)
+
+// normalizeCatalogEntryValue converts a literal value for an attribute into the canonical
+// form the API uses for that attribute type, so that `true`, `"True"` and `"TRUE"` are all
+// sent as "true", and `1.0` is sent as "1".
+//
+// Values for attributes of any other type are returned unchanged.
+func normalizeCatalogEntryValue(attribute client.CatalogTypeAttributeV2, literal string) (string, error) {
+	switch attribute.Type {
+	case catalogAttributeTypeBool:
+		value, err := strconv.ParseBool(strings.ToLower(strings.TrimSpace(literal)))
+		if err != nil {
+			return "", fmt.Errorf("%q is not a valid boolean", literal)
+		}
+
+		return strconv.FormatBool(value), nil
+	case catalogAttributeTypeNumber:
+		value, ok := normalizeCatalogNumber(strings.TrimSpace(literal))
+		if !ok {
+			return "", fmt.Errorf("%q is not a valid number", literal)
+		}
+
+		return value, nil
+	case catalogAttributeTypeTimestamp:
+		value, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(literal))
+		if err != nil {
+			return "", fmt.Errorf("%q is not a valid RFC3339 timestamp", literal)
+		}
+
+		return value.UTC().Format(time.RFC3339Nano), nil
+	}
+
+	return literal, nil
+}
+
+var catalogNumberPattern = regexp.MustCompile(`^([+-]?)([0-9]*)(?:\.([0-9]*))?(?:[eE]([+-]?[0-9]+))?$`)
+
+// normalizeCatalogNumber removes the parts of how a decimal number is written that don't
+// change its value: a plus sign, leading and trailing zeros, a trailing decimal point, and
+// a zero exponent. We work on the text rather than parsing it as a float, so integers too
+// large for a float64 and numbers with large exponents are kept exactly as they are.
+func normalizeCatalogNumber(literal string) (string, bool) {
+	match := catalogNumberPattern.FindStringSubmatch(literal)
+	if match == nil || match[2]+match[3] == "" {
+		return "", false
+	}
+
+	integer, fraction := strings.TrimLeft(match[2], "0"), strings.TrimRight(match[3], "0")
+	if integer == "" {
+		integer = "0"
+	}
+
+	result := integer
+	if fraction != "" {
+		result += "." + fraction
+	}
+	if result == "0" {
+		return result, true
+	}
+	if match[1] == "-" {
+		result = "-" + result
+	}
+
+	if exponent := strings.TrimLeft(match[4], "+-0"); exponent != "" {
+		if strings.HasPrefix(match[4], "-") {
+			exponent = "-" + exponent
+		}
+		result += "e" + exponent
+	}
+
+	return result, true
+}
+
+// catalogEntryValuesEquivalent returns true if two literal values for an attribute mean the
+// same thing once the API has canonicalized them, such as timestamps in different offsets,
+// booleans in a different case or text with surrounding whitespace.
+func catalogEntryValuesEquivalent(attribute client.CatalogTypeAttributeV2, left, right string) bool {
+	if left == right {
+		return true
+	}
+
+	normalizedLeft, leftErr := normalizeCatalogEntryValue(attribute, left)
+	normalizedRight, rightErr := normalizeCatalogEntryValue(attribute, right)
+	if leftErr == nil && rightErr == nil && normalizedLeft == normalizedRight {
+		return true
+	}
+
+	return strings.TrimSpace(left) == strings.TrimSpace(right)
+}
+
+// catalogEntryBindingsEquivalent compares the attribute values we want to send for an
+// entry against those the API currently has, using catalogEntryValuesEquivalent for each
+// literal. Bindings with no value at all, which the API omits, are treated as missing.
+func catalogEntryBindingsEquivalent(attributes map[string]client.CatalogTypeAttributeV2, want, current map[string]client.EngineParamBindingPayloadV2) bool {
+	isEmpty := func(binding client.EngineParamBindingPayloadV2) bool {
+		return binding.Value == nil && (binding.ArrayValue == nil || len(*binding.ArrayValue) == 0)
+	}
+	literal := func(value *client.EngineParamBindingValuePayloadV2) *string {
+		if value == nil {
+			return nil
+		}
+
+		return value.Literal
+	}
+	literalsEquivalent := func(attribute client.CatalogTypeAttributeV2, left, right *string) bool {
+		if left == nil || right == nil {
+			return left == right
+		}
+
+		return catalogEntryValuesEquivalent(attribute, *left, *right)
+	}
+
+	attributeIDs := map[string]bool{}
+	for attributeID := range want {
+		attributeIDs[attributeID] = true
+	}
+	for attributeID := range current {
+		attributeIDs[attributeID] = true
+	}
+
+	for attributeID := range attributeIDs {
+		wantBinding, currentBinding := want[attributeID], current[attributeID]
+		if isEmpty(wantBinding) && isEmpty(currentBinding) {
+			continue
+		}
+		if isEmpty(wantBinding) != isEmpty(currentBinding) {
+			return false
+		}
+
+		attribute := attributes[attributeID]
+		if (wantBinding.Value == nil) != (currentBinding.Value == nil) {
+			return false
+		}
+		if !literalsEquivalent(attribute, literal(wantBinding.Value), literal(currentBinding.Value)) {
+			return false
+		}
+
+		var wantArray, currentArray []client.EngineParamBindingValuePayloadV2
+		if wantBinding.ArrayValue != nil {
+			wantArray = *wantBinding.ArrayValue
+		}
+		if currentBinding.ArrayValue != nil {
+			currentArray = *currentBinding.ArrayValue
+		}
+		if len(wantArray) != len(currentArray) {
+			return false
+		}
+		for idx := range wantArray {
+			if !literalsEquivalent(attribute, wantArray[idx].Literal, currentArray[idx].Literal) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/incident-io/terraform-provider-incident/internal/client"
+)
+
+func TestNormalizeCatalogEntryValue(t *testing.T) {
+	testCases := []struct {
+		name      string
+		typeName  string
+		literal   string
+		want      string
+		wantError bool
+	}{
+		{name: "bool lowercase", typeName: catalogAttributeTypeBool, literal: "true", want: "true"},
+		{name: "bool mixed case", typeName: catalogAttributeTypeBool, literal: "False", want: "false"},
+		{name: "bool upper case with whitespace", typeName: catalogAttributeTypeBool, literal: " TRUE ", want: "true"},
+		{name: "bool digit", typeName: catalogAttributeTypeBool, literal: "1", want: "true"},
+		{name: "bool invalid", typeName: catalogAttributeTypeBool, literal: "yes", wantError: true},
+
+		{name: "number integer", typeName: catalogAttributeTypeNumber, literal: "42", want: "42"},
+		{name: "number trailing zeros", typeName: catalogAttributeTypeNumber, literal: "1.50", want: "1.5"},
+		{name: "number whole decimal", typeName: catalogAttributeTypeNumber, literal: "1.0", want: "1"},
+		{name: "number trailing point", typeName: catalogAttributeTypeNumber, literal: "7.", want: "7"},
+		{name: "number leading zeros", typeName: catalogAttributeTypeNumber, literal: "007", want: "7"},
+		{name: "number leading point", typeName: catalogAttributeTypeNumber, literal: ".25", want: "0.25"},
+		{name: "number plus sign", typeName: catalogAttributeTypeNumber, literal: "+3", want: "3"},
+		{name: "number negative", typeName: catalogAttributeTypeNumber, literal: "-0.50", want: "-0.5"},
+		{name: "number negative zero", typeName: catalogAttributeTypeNumber, literal: "-0.0", want: "0"},
+		{name: "number larger than a float64 can hold exactly", typeName: catalogAttributeTypeNumber, literal: "12345678901234567890", want: "12345678901234567890"},
+		{name: "number large exponent", typeName: catalogAttributeTypeNumber, literal: "1e400", want: "1e400"},
+		{name: "number exponent with zeros", typeName: catalogAttributeTypeNumber, literal: "2.50E+007", want: "2.5e7"},
+		{name: "number negative exponent", typeName: catalogAttributeTypeNumber, literal: "1e-05", want: "1e-5"},
+		{name: "number zero exponent", typeName: catalogAttributeTypeNumber, literal: "4e0", want: "4"},
+		{name: "number with whitespace", typeName: catalogAttributeTypeNumber, literal: " 10 ", want: "10"},
+		{name: "number invalid", typeName: catalogAttributeTypeNumber, literal: "ten", wantError: true},
+		{name: "number infinity", typeName: catalogAttributeTypeNumber, literal: "Inf", wantError: true},
+		{name: "number only a point", typeName: catalogAttributeTypeNumber, literal: ".", wantError: true},
+
+		{name: "timestamp UTC", typeName: catalogAttributeTypeTimestamp, literal: "2024-01-02T03:04:05Z", want: "2024-01-02T03:04:05Z"},
+		{name: "timestamp with offset", typeName: catalogAttributeTypeTimestamp, literal: "2024-01-01T00:30:00+01:00", want: "2023-12-31T23:30:00Z"},
+		{name: "timestamp with fractional seconds", typeName: catalogAttributeTypeTimestamp, literal: "2024-01-02T03:04:05.120Z", want: "2024-01-02T03:04:05.12Z"},
+		{name: "timestamp invalid", typeName: catalogAttributeTypeTimestamp, literal: "2024-01-02", wantError: true},
+
+		{name: "text is unchanged", typeName: "Text", literal: " Hello ", want: " Hello "},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := normalizeCatalogEntryValue(client.CatalogTypeAttributeV2{Type: tc.typeName}, tc.literal)
+			if tc.wantError {
+				if err == nil {
+					t.Errorf("expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
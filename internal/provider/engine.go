@@ -2,6 +2,7 @@ package provider
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/incident-io/terraform-provider-incident/internal/apischema"
 )
@@ -161,8 +162,9 @@ var returnsAttribute = schema.SingleNestedAttribute{
 }
 
 var expressionsAttribute = schema.SetNestedAttribute{
-	MarkdownDescription: "The expressions to be prepared for use by steps and conditions",
-	Required:            true,
+	MarkdownDescription: "The expressions to be prepared for use by steps and conditions. Can't be used with expressions_json.",
+	Optional:            true,
+	Computed:            true,
 	NestedObject: schema.NestedAttributeObject{
 		Attributes: map[string]schema.Attribute{
 			"label": schema.StringAttribute{
@@ -233,6 +235,9 @@ var expressionsAttribute = schema.SetNestedAttribute{
 						"operation_type": schema.StringAttribute{
 							MarkdownDescription: "Indicates which operation type to execute",
 							Required:            true,
+							Validators: []validator.String{
+								apischema.OneOf("ExpressionOperationPayloadV2RequestBody", "operation_type"),
+							},
 						},
 						"parse": schema.SingleNestedAttribute{
 							MarkdownDescription: "An operation type that allows a value to parsed from within a JSON object",
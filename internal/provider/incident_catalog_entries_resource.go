@@ -2,18 +2,33 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
+	"math"
+	"net/http"
 	"reflect"
-
-	"github.com/davecgh/go-spew/spew"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/incident-io/terraform-provider-incident/internal/apischema"
@@ -24,17 +39,73 @@ import (
 )
 
 var (
-	_ resource.Resource                = &IncidentCatalogEntriesResource{}
-	_ resource.ResourceWithImportState = &IncidentCatalogEntriesResource{}
+	_ resource.Resource                   = &IncidentCatalogEntriesResource{}
+	_ resource.ResourceWithImportState    = &IncidentCatalogEntriesResource{}
+	_ resource.ResourceWithModifyPlan     = &IncidentCatalogEntriesResource{}
+	_ resource.ResourceWithValidateConfig = &IncidentCatalogEntriesResource{}
+)
+
+const (
+	// catalogEntriesMaxPageSize is the largest page size the list entries API will accept.
+	catalogEntriesMaxPageSize = 250
+
+	// catalogEntriesMaxEntries is the most entries the API supports in a single catalog type.
+	catalogEntriesMaxEntries = 50000
+
+	catalogEntriesDefaultPageSize    = catalogEntriesMaxPageSize
+	catalogEntriesDefaultConcurrency = 10
+	catalogEntriesDefaultMaxRetries  = 3
+
+	// catalogEntriesDefaultTimeout is how long we allow for a sync unless the timeouts block
+	// says otherwise. This is far longer than other resources as a sync can involve many
+	// thousands of requests.
+	catalogEntriesDefaultTimeout = 2 * time.Hour
+
+	// catalogEntriesDestroyBehaviorDelete deletes every entry when the resource is
+	// destroyed, while catalogEntriesDestroyBehaviorAbandon leaves them in place.
+	catalogEntriesDestroyBehaviorDelete  = "delete"
+	catalogEntriesDestroyBehaviorAbandon = "abandon"
 )
 
 type IncidentCatalogEntriesResource struct {
-	client *client.ClientWithResponses
+	client                       *client.ClientWithResponses
+	apiUsage                     *apiUsage
+	protectDestructiveOperations bool
+	validateReferences           bool
 }
 
 type IncidentCatalogEntriesResourceModel struct {
-	ID      types.String                 `tfsdk:"id"` // Catalog Type ID
-	Entries map[string]CatalogEntryModel `tfsdk:"entries"`
+	ID                     types.String                 `tfsdk:"id"` // Catalog Type ID
+	Entries                map[string]CatalogEntryModel `tfsdk:"entries"`
+	EntriesJSON            types.String                 `tfsdk:"entries_json"`
+	PageSize               types.Int64                  `tfsdk:"page_size"`
+	CreateConcurrency      types.Int64                  `tfsdk:"create_concurrency"`
+	DeleteConcurrency      types.Int64                  `tfsdk:"delete_concurrency"`
+	MaxRetries             types.Int64                  `tfsdk:"max_retries"`
+	IgnoreRank             types.Bool                   `tfsdk:"ignore_rank"`
+	MaxDelete              types.String                 `tfsdk:"max_delete"`
+	MaxDeleteOverride      types.Bool                   `tfsdk:"max_delete_override"`
+	AuthoritativeScope     types.String                 `tfsdk:"authoritative_scope"`
+	DeleteUnmanagedEntries types.Bool                   `tfsdk:"delete_unmanaged_entries"`
+	DestroyBehavior        types.String                 `tfsdk:"destroy_behavior"`
+	RankBy                 types.String                 `tfsdk:"rank_by"`
+
+	AllowDestructiveOperations types.Bool `tfsdk:"allow_destructive_operations"`
+
+	EntriesCount        types.Int64    `tfsdk:"entries_count"`
+	EntriesHash         types.String   `tfsdk:"entries_hash"`
+	UnmanagedEntryCount types.Int64    `tfsdk:"unmanaged_entry_count"`
+	UnmanagedEntryIDs   types.List     `tfsdk:"unmanaged_entry_ids"`
+	Drift               types.Object   `tfsdk:"drift"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
+}
+
+// catalogEntriesDriftAttributeTypes describes the drift attribute, which reports the
+// changes we found to our entries the last time they were refreshed.
+var catalogEntriesDriftAttributeTypes = map[string]attr.Type{
+	"changed_external_ids": types.ListType{ElemType: types.StringType},
+	"missing_external_ids": types.ListType{ElemType: types.StringType},
+	"added_external_ids":   types.ListType{ElemType: types.StringType},
 }
 
 type CatalogEntryModel struct {
@@ -67,7 +138,11 @@ This resource manages all entries for a given catalog type and should be used wh
 loading many (>100) catalog entries to ensure fast and reliable plans.
 
 Please note that this resource is authoritative, in that it will delete _all_ entries from
-the catalog type that it doesn't manage, even those created outside of Terraform.
+the catalog type that it doesn't manage, even those created outside of Terraform. If you
+need to share a catalog type with other tooling, use authoritative_scope to limit which
+entries this resource owns. If entries are also added through the dashboard, set
+delete_unmanaged_entries to false: any without an external ID are then reported in
+unmanaged_entry_ids rather than deleted.
 
 If you have a catalog source such as Backstage or some custom catalog you'd like to sync
 into incident.io, this is the recommended way of achieving that.
@@ -85,6 +160,28 @@ We call this the 'external ID' and it might be something like:
 
 This external ID is what we use as a map key for the entries attribute, and how we map
 changes to one entry to an update to that same entry when the upstream changes.
+
+## Loading entries from JSON
+
+For very large catalogs it can be expensive to transform source data into HCL. As an
+alternative to the entries attribute, you can provide the same entries as a JSON document
+using entries_json, typically loaded using the file() function:
+
+` + "```json" + `
+{
+  "payments-api": {
+    "name": "Payments API",
+    "aliases": ["payments"],
+    "attribute_values": {
+      "Description": { "value": "Takes money from customers" },
+      "Owners": { "array_value": ["payments-team"] }
+    }
+  }
+}
+` + "```" + `
+
+Entries are reconciled in exactly the same way, and will still be shown in the entries
+attribute of the plan.
 		`,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -95,51 +192,168 @@ changes to one entry to an update to that same entry when the upstream changes.
 				Required: true,
 			},
 			"entries": schema.MapNestedAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: `Map of external ID to entry in the catalog. Exactly one of entries or entries_json must be set.`,
+				NestedObject:        catalogEntryNestedAttributeObject(),
+			},
+			"entries_json": schema.StringAttribute{
+				MarkdownDescription: `JSON object of external ID to entry in the catalog, in the same shape as the entries attribute. Exactly one of entries or entries_json must be set.`,
+				Optional:            true,
+			},
+			"ignore_rank": schema.BoolAttribute{
+				MarkdownDescription: "If true, the rank of entries is neither sent to nor diffed against the API, leaving it to be managed elsewhere.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"authoritative_scope": schema.StringAttribute{
+				MarkdownDescription: "Regular expression limiting which entries this resource owns, by external ID (e.g. `^team-a/` to own every entry whose external ID starts with `team-a/`). When set, entries outside the scope, including any with no external ID, are neither tracked nor deleted, and every configured entry must be within the scope.",
+				Optional:            true,
+			},
+			"delete_unmanaged_entries": schema.BoolAttribute{
+				MarkdownDescription: "If false, entries with no external ID, such as those created in the dashboard, are left in the catalog type and reported in unmanaged_entry_ids rather than deleted. Defaults to true.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"max_delete": schema.StringAttribute{
+				MarkdownDescription: "Maximum number of entries that can be deleted when applying, either as a count (e.g. `100`) or a percentage of the entries in the catalog type (e.g. `10%`). Applies that would delete more fail without making any changes, protecting against an upstream source accidentally producing no entries. Does not apply when destroying the resource.",
+				Optional:            true,
+			},
+			"max_delete_override": schema.BoolAttribute{
+				MarkdownDescription: "If true, allows an apply to delete more entries than max_delete would otherwise permit.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"entries_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of entries managed by this resource.",
+				Computed:            true,
+			},
+			"entries_hash": schema.StringAttribute{
+				MarkdownDescription: "SHA256 hash of the content of the entries managed by this resource, which changes whenever any of them do. Use this to trigger other resources or checks when the catalog changes, without comparing every entry.",
+				Computed:            true,
+			},
+			"unmanaged_entry_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of entries in the catalog type that have no external ID. Unless delete_unmanaged_entries is false, these will be deleted when this resource is next applied.",
+				Computed:            true,
+			},
+			"unmanaged_entry_ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of the entries in the catalog type that have no external ID. Unless delete_unmanaged_entries is false, these will be deleted when this resource is next applied.",
+				Computed:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Number of times to retry creating, updating or deleting an entry when the request fails with a transient error, such as being rate limited, before failing the apply.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(catalogEntriesDefaultMaxRetries),
+			},
+			"destroy_behavior": schema.StringAttribute{
+				MarkdownDescription: "What to do with the entries when this resource is destroyed: `delete` (the default) deletes every entry this resource manages, while `abandon` leaves them in the catalog, such as when moving their management to another tool.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(catalogEntriesDestroyBehaviorDelete),
+			},
+			"allow_destructive_operations": schema.BoolAttribute{
+				MarkdownDescription: "If true, this resource can delete every entry it manages, such as when it's destroyed, even when the provider sets `protect_destructive_operations`. This must be applied before the destroy is planned.",
+				Optional:            true,
+			},
+			"drift": schema.SingleNestedAttribute{
+				MarkdownDescription: "Changes to entries made outside of Terraform, as found when this resource was last refreshed. This is cleared once the resource is applied.",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"changed_external_ids": schema.ListAttribute{
+						ElementType:         types.StringType,
+						MarkdownDescription: "External IDs of entries that were changed outside of Terraform.",
+						Computed:            true,
+					},
+					"missing_external_ids": schema.ListAttribute{
+						ElementType:         types.StringType,
+						MarkdownDescription: "External IDs of entries that were deleted outside of Terraform.",
+						Computed:            true,
+					},
+					"added_external_ids": schema.ListAttribute{
+						ElementType:         types.StringType,
+						MarkdownDescription: "External IDs of entries that were added outside of Terraform, such as by another integration.",
+						Computed:            true,
+					},
+				},
+			},
+			"rank_by": schema.StringAttribute{
+				MarkdownDescription: "Derive the rank of each entry from a sort key, rather than setting rank on each entry. Either `name`, `external_id` or the ID or name of an attribute of the catalog type. Entries are ranked in ascending order of that key, starting from 1, with ties broken by external ID. Numeric values are compared as numbers.",
+				Optional:            true,
+			},
+			"page_size": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of entries to request per page when listing the entries of the catalog type, up to a maximum of %d.", catalogEntriesMaxPageSize),
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(catalogEntriesDefaultPageSize),
+			},
+			"create_concurrency": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of entries to create or update concurrently. Fewer are changed at once when the API reports we are close to our rate limit.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(catalogEntriesDefaultConcurrency),
+			},
+			"delete_concurrency": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of entries to delete concurrently. Fewer are deleted at once when the API reports we are close to our rate limit.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(catalogEntriesDefaultConcurrency),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+// catalogEntryNestedAttributeObject is the schema for a single catalog entry, shared by
+// every resource that manages entries keyed by their external ID.
+func catalogEntryNestedAttributeObject() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: apischema.Docstring("CatalogEntryV2ResponseBody", "id"),
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: apischema.Docstring("CatalogEntryV2ResponseBody", "name"),
+				Required:            true,
+			},
+			"aliases": schema.ListAttribute{
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: apischema.Docstring("CatalogEntryV2ResponseBody", "aliases"),
+				Optional:            true,
+				Computed:            true,
+			},
+			"rank": schema.Int64Attribute{
+				MarkdownDescription: apischema.Docstring("CatalogEntryV2ResponseBody", "rank"),
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+			},
+			"attribute_values": schema.MapNestedAttribute{
+				MarkdownDescription: "Map of attribute ID or name to the value of that attribute for this entry. Attribute names are resolved against the schema of the catalog type when planning and applying.",
 				Required:            true,
-				MarkdownDescription: `Map of external ID to entry in the catalog.`,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"id": schema.StringAttribute{
-							MarkdownDescription: apischema.Docstring("CatalogEntryV2ResponseBody", "id"),
-							Computed:            true,
-							PlanModifiers: []planmodifier.String{
-								stringplanmodifier.UseStateForUnknown(),
-							},
+						"value": schema.StringAttribute{
+							Description: `The value of this attribute, in a format suitable for this attribute type. Bool, Number and Timestamp values are normalized, so true and "True" or 1.0 and "1" are equivalent. For attributes that reference another catalog type, this can be the ID, external ID or any alias of the referenced entry.`,
+							Optional:    true,
 						},
-						"name": schema.StringAttribute{
-							MarkdownDescription: apischema.Docstring("CatalogEntryV2ResponseBody", "name"),
-							Required:            true,
-						},
-						"aliases": schema.ListAttribute{
+						"array_value": schema.ListAttribute{
 							ElementType: types.StringType,
-							PlanModifiers: []planmodifier.List{
-								listplanmodifier.UseStateForUnknown(),
-							},
-							MarkdownDescription: apischema.Docstring("CatalogEntryV2ResponseBody", "aliases"),
-							Optional:            true,
-							Computed:            true,
-						},
-						"rank": schema.Int64Attribute{
-							MarkdownDescription: apischema.Docstring("CatalogEntryV2ResponseBody", "rank"),
-							Optional:            true,
-							Computed:            true,
-							Default:             int64default.StaticInt64(0),
-						},
-						"attribute_values": schema.MapNestedAttribute{
-							Required: true,
-							NestedObject: schema.NestedAttributeObject{
-								Attributes: map[string]schema.Attribute{
-									"value": schema.StringAttribute{
-										Description: `The value of this attribute, in a format suitable for this attribute type.`,
-										Optional:    true,
-									},
-									"array_value": schema.ListAttribute{
-										ElementType: types.StringType,
-										Description: `The value of this element of the array, in a format suitable for this attribute type.`,
-										Optional:    true,
-									},
-								},
-							},
+							Description: `The value of this element of the array, in a format suitable for this attribute type. Elements are normalized in the same way as value. For attributes that reference another catalog type, elements can be the ID, external ID or any alias of the referenced entry.`,
+							Optional:    true,
 						},
 					},
 				},
@@ -164,72 +378,157 @@ func (r *IncidentCatalogEntriesResource) Configure(ctx context.Context, req reso
 	}
 
 	r.client = client.Client
+	r.apiUsage = client.APIUsage
+	r.protectDestructiveOperations = client.ProtectDestructiveOperations
+	r.validateReferences = client.ValidateReferences
 }
 
 func (r *IncidentCatalogEntriesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_entries", "Create")
+	defer done()
+
 	var data *IncidentCatalogEntriesResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	catalogType, entries, err := r.reconcile(ctx, data)
+	createTimeout, diags := data.Timeouts.Create(ctx, catalogEntriesDefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	refs := newCatalogEntryReferences(r)
+	catalogType, entries, err := r.reconcile(ctx, data, refs)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", err.Error())
+		addReconcileError(&resp.Diagnostics, path.Root("entries"), err)
 		return
 	}
 
-	data = r.buildModel(*catalogType, entries, data)
+	data, err = r.buildModel(ctx, *catalogType, entries, data, refs)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", err.Error())
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *IncidentCatalogEntriesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_entries", "Read")
+	defer done()
+
 	var data *IncidentCatalogEntriesResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	catalogType, entries, err := r.getEntries(ctx, data.ID.ValueString())
+	readTimeout, diags := data.Timeouts.Read(ctx, catalogEntriesDefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	catalogType, entries, err := r.getEntries(ctx, data.ID.ValueString(), data.pageSize())
+	if isNotFound(err) {
+		resp.Diagnostics.AddWarning("Not Found", fmt.Sprintf("Unable to find catalog type with ID %s, removing entries from state.", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list entries, got error: %s", err))
 		return
 	}
 
-	data = r.buildModel(*catalogType, entries, data)
+	prior := data
+	data, err = r.buildModel(ctx, *catalogType, entries, prior, newCatalogEntryReferences(r))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", err.Error())
+		return
+	}
+
+	// We don't have any prior entries just after an import, so there's no drift to report.
+	if prior.Entries != nil {
+		data.Drift = buildCatalogEntriesDrift(prior.Entries, data.Entries)
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *IncidentCatalogEntriesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_entries", "Update")
+	defer done()
+
 	var data *IncidentCatalogEntriesResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	catalogType, entries, err := r.reconcile(ctx, data)
+	updateTimeout, diags := data.Timeouts.Update(ctx, catalogEntriesDefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	refs := newCatalogEntryReferences(r)
+	catalogType, entries, err := r.reconcile(ctx, data, refs)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", err.Error())
+		addReconcileError(&resp.Diagnostics, path.Root("entries"), err)
 		return
 	}
 
-	data = r.buildModel(*catalogType, entries, data)
+	data, err = r.buildModel(ctx, *catalogType, entries, data, refs)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", err.Error())
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *IncidentCatalogEntriesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_entries", "Delete")
+	defer done()
+
 	var data *IncidentCatalogEntriesResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, catalogEntriesDefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if data.DestroyBehavior.ValueString() == catalogEntriesDestroyBehaviorAbandon {
+		tflog.Info(ctx, fmt.Sprintf("destroy_behavior is abandon, leaving %d entries in catalog type id=%s", len(data.Entries), data.ID.ValueString()))
+		return
+	}
+
 	// Set entries to an empty list.
 	data.Entries = map[string]CatalogEntryModel{}
 
-	catalogType, entries, err := r.reconcile(ctx, data)
+	// Destroying the resource is an explicit request to delete every entry, so the deletion
+	// threshold shouldn't apply.
+	data.MaxDelete = types.StringNull()
+
+	catalogType, entries, err := r.reconcile(ctx, data, newCatalogEntryReferences(r))
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", err.Error())
+		addReconcileError(&resp.Diagnostics, path.Root("entries"), err)
 		return
 	}
 	if len(entries) > 0 {
@@ -238,23 +537,379 @@ func (r *IncidentCatalogEntriesResource) Delete(ctx context.Context, req resourc
 	}
 }
 
+func (r *IncidentCatalogEntriesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var (
+		entries     types.Map
+		entriesJSON types.String
+	)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("entries"), &entries)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("entries_json"), &entriesJSON)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if entries.IsNull() == entriesJSON.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("entries_json"), "Invalid catalog entries",
+			"Exactly one of entries or entries_json must be set.")
+	}
+	if !entriesJSON.IsNull() && !entriesJSON.IsUnknown() {
+		if _, err := parseCatalogEntriesJSON(entriesJSON.ValueString(), nil); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("entries_json"), "Invalid catalog entries JSON", err.Error())
+		}
+	}
+
+	var authoritativeScope types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("authoritative_scope"), &authoritativeScope)...)
+	if !authoritativeScope.IsNull() && !authoritativeScope.IsUnknown() {
+		if _, err := regexp.Compile(authoritativeScope.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("authoritative_scope"), "Invalid authoritative scope", err.Error())
+		}
+	}
+
+	var rankBy types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rank_by"), &rankBy)...)
+	if !rankBy.IsNull() {
+		var ignoreRank types.Bool
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ignore_rank"), &ignoreRank)...)
+		if ignoreRank.ValueBool() {
+			resp.Diagnostics.AddAttributeError(path.Root("rank_by"), "Conflicting rank options",
+				"rank_by can't be used when ignore_rank is true.")
+		}
+
+		if !entries.IsNull() && !entries.IsUnknown() {
+			configured := map[string]CatalogEntryModel{}
+			if entryDiags := entries.ElementsAs(ctx, &configured, false); !entryDiags.HasError() {
+				externalIDs := lo.Keys(configured)
+				sort.Strings(externalIDs)
+				for _, externalID := range externalIDs {
+					if !configured[externalID].Rank.IsNull() {
+						resp.Diagnostics.AddAttributeError(path.Root("entries").AtMapKey(externalID).AtName("rank"), "Conflicting rank options",
+							"Entries can't set rank when rank_by is used.")
+					}
+				}
+			}
+		}
+	}
+
+	var destroyBehavior types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("destroy_behavior"), &destroyBehavior)...)
+	if !destroyBehavior.IsNull() && !destroyBehavior.IsUnknown() {
+		switch destroyBehavior.ValueString() {
+		case catalogEntriesDestroyBehaviorDelete, catalogEntriesDestroyBehaviorAbandon:
+		default:
+			resp.Diagnostics.AddAttributeError(path.Root("destroy_behavior"), "Invalid destroy behavior",
+				fmt.Sprintf("Destroy behavior must be one of %q or %q, got %q.", catalogEntriesDestroyBehaviorDelete, catalogEntriesDestroyBehaviorAbandon, destroyBehavior.ValueString()))
+		}
+	}
+
+	var maxDelete types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("max_delete"), &maxDelete)...)
+	if !maxDelete.IsNull() && !maxDelete.IsUnknown() {
+		if _, err := parseMaxDelete(maxDelete.ValueString(), 0); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("max_delete"), "Invalid max_delete", err.Error())
+		}
+	}
+
+	var pageSize types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("page_size"), &pageSize)...)
+	if !pageSize.IsNull() && !pageSize.IsUnknown() {
+		if size := pageSize.ValueInt64(); size < 1 || size > catalogEntriesMaxPageSize {
+			resp.Diagnostics.AddAttributeError(path.Root("page_size"), "Invalid page size",
+				fmt.Sprintf("Page size must be between 1 and %d, got %d.", catalogEntriesMaxPageSize, size))
+		}
+	}
+
+	var maxRetries types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("max_retries"), &maxRetries)...)
+	if !maxRetries.IsNull() && !maxRetries.IsUnknown() && maxRetries.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_retries"), "Invalid max retries",
+			fmt.Sprintf("Max retries must not be negative, got %d.", maxRetries.ValueInt64()))
+	}
+
+	for _, attribute := range []string{"create_concurrency", "delete_concurrency"} {
+		var concurrency types.Int64
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &concurrency)...)
+		if !concurrency.IsNull() && !concurrency.IsUnknown() && concurrency.ValueInt64() < 1 {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute), "Invalid concurrency",
+				fmt.Sprintf("Concurrency must be at least 1, got %d.", concurrency.ValueInt64()))
+		}
+	}
+}
+
+// ModifyPlan validates the planned entries against the schema of the catalog type, so that
+// mistakes are caught at plan time rather than failing entry-by-entry part way through an
+// apply.
+//
+// It also summarises how many entries will change, as the diff for a large catalog can be
+// too long for anyone to review properly, and plans entries_count and entries_hash so that
+// anything depending on them sees the change in the same plan.
+//
+// When the entries are provided as JSON, this is where we expand them into the entries
+// attribute so they are planned and reconciled as if they had been configured directly.
+func (r *IncidentCatalogEntriesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_entries", "ModifyPlan")
+	defer done()
+
+	// Nothing to validate when we're being destroyed, other than whether we're allowed to.
+	if req.Plan.Raw.IsNull() {
+		if r.protectDestructiveOperations {
+			var state *IncidentCatalogEntriesResourceModel
+			resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			if state.destroyBehavior() == catalogEntriesDestroyBehaviorDelete {
+				if err := r.checkDestructiveOperation(state, len(state.Entries)); err != nil {
+					resp.Diagnostics.AddError("Destructive Operation Prevented", err.Error())
+				}
+			}
+		}
+
+		return
+	}
+
+	// Applying deletes any unmanaged entries, so we plan for there to be none. This also
+	// means any that appear outside of terraform will show as a diff. If we're leaving them
+	// alone, we expect to find the same ones we did when we last refreshed.
+	var deleteUnmanaged types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("delete_unmanaged_entries"), &deleteUnmanaged)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if deleteUnmanaged.IsNull() || deleteUnmanaged.ValueBool() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("unmanaged_entry_count"), types.Int64Value(0))...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("unmanaged_entry_ids"), types.ListValueMust(types.StringType, []attr.Value{}))...)
+	} else if !req.State.Raw.IsNull() {
+		var unmanagedEntryCount types.Int64
+		var unmanagedEntryIDs types.List
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("unmanaged_entry_count"), &unmanagedEntryCount)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("unmanaged_entry_ids"), &unmanagedEntryIDs)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("unmanaged_entry_count"), unmanagedEntryCount)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("unmanaged_entry_ids"), unmanagedEntryIDs)...)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("drift"), buildCatalogEntriesDrift(nil, nil))...)
+
+	var catalogTypeID types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("id"), &catalogTypeID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if catalogTypeID.IsUnknown() {
+		// We can't rank entries until we know their catalog type, so leave the ranks to be
+		// worked out when we plan again during apply.
+		var rankBy types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rank_by"), &rankBy)...)
+		if !rankBy.IsNull() {
+			if entries, ok := plannedCatalogEntries(ctx, resp.Plan, path.Root("entries"), &resp.Diagnostics); ok {
+				for externalID, entry := range entries {
+					entry.Rank = types.Int64Unknown()
+					entries[externalID] = entry
+				}
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("entries"), entries)...)
+			}
+		}
+
+		return
+	}
+	if r.validateReferences {
+		validateReference(ctx, &resp.Diagnostics, path.Root("id"), "catalog type", catalogTypeID, catalogTypeExists(r.client))
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var state *IncidentCatalogEntriesResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var entriesJSON types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("entries_json"), &entriesJSON)...)
+	if resp.Diagnostics.HasError() || entriesJSON.IsUnknown() {
+		return
+	}
+	if !entriesJSON.IsNull() {
+		var current map[string]CatalogEntryModel
+		if state != nil {
+			current = state.Entries
+		}
+
+		entries, err := parseCatalogEntriesJSON(entriesJSON.ValueString(), current)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("entries_json"), "Invalid catalog entries JSON", err.Error())
+			return
+		}
+
+		// As in ValidateConfig for the entries attribute, ranks would be overwritten by rank_by.
+		var rankBy types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rank_by"), &rankBy)...)
+		if !rankBy.IsNull() {
+			for _, externalID := range rankedCatalogEntriesJSON(entriesJSON.ValueString()) {
+				resp.Diagnostics.AddAttributeError(path.Root("entries_json"), "Conflicting rank options",
+					fmt.Sprintf("Entry %q can't set rank when rank_by is used.", externalID))
+			}
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("entries"), entries)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	entries, ok := plannedCatalogEntries(ctx, resp.Plan, path.Root("entries"), &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	resp.Diagnostics.Append(validateCatalogEntriesUnique(ctx, path.Root("entries"), entries)...)
+
+	var authoritativeScope types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("authoritative_scope"), &authoritativeScope)...)
+	if !authoritativeScope.IsNull() && !authoritativeScope.IsUnknown() {
+		if scope, err := regexp.Compile(authoritativeScope.ValueString()); err == nil {
+			externalIDs := lo.Keys(entries)
+			sort.Strings(externalIDs)
+			for _, externalID := range externalIDs {
+				if !scope.MatchString(externalID) {
+					resp.Diagnostics.AddAttributeError(path.Root("entries").AtMapKey(externalID), "Catalog entry outside of authoritative scope",
+						fmt.Sprintf("Entry %q does not match the authoritative_scope %q, so can't be managed by this resource.", externalID, authoritativeScope.ValueString()))
+				}
+			}
+		}
+	}
+
+	result, err := r.client.CatalogV2ShowTypeWithResponse(ctx, catalogTypeID.ValueString())
+	if err == nil && result.StatusCode() >= 400 {
+		err = newAPIError(result.StatusCode(), result.Body)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read catalog type, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(validateCatalogEntries(result.JSON200.CatalogType, path.Root("entries"), entries)...)
+
+	var rankBy types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rank_by"), &rankBy)...)
+	if !rankBy.IsNull() && !rankBy.IsUnknown() {
+		if err := rankCatalogEntries(result.JSON200.CatalogType, entries, rankBy.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("rank_by"), "Invalid rank_by", err.Error())
+			return
+		}
+
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("entries"), entries)...)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("entries_count"), types.Int64Value(int64(len(entries))))...)
+	if hash, ok := catalogEntriesHash(entries); ok {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("entries_hash"), types.StringValue(hash))...)
+	}
+
+	if summary := summariseCatalogEntryChanges(state, entries); summary != "" {
+		resp.Diagnostics.AddWarning("Catalog entries will change", summary)
+	}
+}
+
+// ImportState accepts the ID, type name (e.g. Custom["Service"]) or name of the catalog
+// type whose entries we want to import, resolving it to the catalog type ID.
 func (r *IncidentCatalogEntriesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_entries", "ImportState")
+	defer done()
+
+	result, err := r.client.CatalogV2ListTypesWithResponse(ctx)
+	if err == nil && result.StatusCode() >= 400 {
+		err = newAPIError(result.StatusCode(), result.Body)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list catalog types, got error: %s", err))
+		return
+	}
+
+	catalogTypes := result.JSON200.CatalogTypes
+	catalogType, ok := lo.Find(catalogTypes, func(catalogType client.CatalogTypeV2) bool {
+		return catalogType.Id == req.ID
+	})
+	if !ok {
+		catalogType, ok = lo.Find(catalogTypes, func(catalogType client.CatalogTypeV2) bool {
+			return catalogType.TypeName == req.ID
+		})
+	}
+	if !ok {
+		matches := lo.Filter(catalogTypes, func(catalogType client.CatalogTypeV2, _ int) bool {
+			return catalogType.Name == req.ID
+		})
+		if len(matches) > 1 {
+			resp.Diagnostics.AddError("Ambiguous Import", fmt.Sprintf("Found %d catalog types named %q, please import using the ID or type name instead.", len(matches), req.ID))
+			return
+		}
+		if len(matches) == 1 {
+			catalogType, ok = matches[0], true
+		}
+	}
+	if !ok {
+		resp.Diagnostics.AddError("Not Found", fmt.Sprintf("Unable to find a catalog type with ID, type name or name %q.", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), catalogType.Id)...)
 }
 
 // buildModel generates a terraform model from a catalog type and current list of all
 // entries, as received from getEntries.
-func (r *IncidentCatalogEntriesResource) buildModel(catalogType client.CatalogTypeV2, entries []client.CatalogEntryV2, plan *IncidentCatalogEntriesResourceModel) *IncidentCatalogEntriesResourceModel {
-	modelEntries := map[string]CatalogEntryModel{}
+//
+// Where our plan references other catalog entries by external ID or alias and the API has
+// returned the ID of that same entry, we keep the value from our plan to avoid a diff.
+func (r *IncidentCatalogEntriesResource) buildModel(ctx context.Context, catalogType client.CatalogTypeV2, entries []client.CatalogEntryV2, plan *IncidentCatalogEntriesResourceModel, refs *catalogEntryReferences) (*IncidentCatalogEntriesResourceModel, error) {
+	attributeIDs := buildAttributeIDLookup(catalogType)
+	attributes := lo.KeyBy(catalogType.Schema.Attributes, func(attribute client.CatalogTypeAttributeV2) string {
+		return attribute.Id
+	})
+
+	scope, err := plan.authoritativeScope()
+	if err != nil {
+		return nil, err
+	}
+
+	// Refreshing can involve many thousands of entries, so we size everything up front and
+	// avoid building values we'll only throw away.
+	modelEntries := make(map[string]CatalogEntryModel, len(entries))
+	unmanagedEntryIDs := []attr.Value{}
 	for _, entry := range entries {
 		// Skip all entries that come with no external ID, as these can't have been created by
-		// terraform, and therefore should never be managed by us.
+		// terraform, and therefore should never be managed by us. If we're scoped then these
+		// belong to someone else and won't be deleted, so they're not worth reporting.
 		if entry.ExternalId == nil {
+			if scope == nil {
+				unmanagedEntryIDs = append(unmanagedEntryIDs, types.StringValue(entry.Id))
+			}
+			continue
+		}
+
+		// Entries outside of our scope are owned by someone else.
+		if scope != nil && !scope.MatchString(*entry.ExternalId) {
 			continue
 		}
 
-		values := map[string]CatalogEntryAttributeBindingModel{}
+		planEntry := plan.Entries[*entry.ExternalId]
+
+		values := make(map[string]CatalogEntryAttributeBindingModel, len(entry.AttributeValues))
 		for attributeID, binding := range entry.AttributeValues {
+			// Attribute values may have been keyed by name rather than ID in our plan, in which
+			// case we want to write them back to state under that same key to avoid a diff.
+			attributeKey := attributeID
+			if _, ok := planEntry.AttributeValues[attributeID]; !ok {
+				name := attributes[attributeID].Name
+				if _, ok := planEntry.AttributeValues[name]; ok && attributeIDs[name] == attributeID {
+					attributeKey = name
+				}
+			}
+
 			// For terraform to serialize a list, it must know the type of the list. It's
 			// possible that we won't have any values from the API response that we'd populate
 			// our ArrayValue with, so we default allocate it as a string list so we know how to
@@ -262,6 +917,7 @@ func (r *IncidentCatalogEntriesResource) buildModel(catalogType client.CatalogTy
 			value := CatalogEntryAttributeBindingModel{
 				ArrayValue: types.ListNull(types.StringType),
 			}
+			planBinding := planEntry.AttributeValues[attributeKey]
 
 			// If we have neither value or array value, then we are at risk of the API having
 			// removed the array value that we provided from our state/plan as our API code
@@ -275,7 +931,6 @@ func (r *IncidentCatalogEntriesResource) buildModel(catalogType client.CatalogTy
 				// If our plan included an empty array then assume the API dropped it when
 				// responding, and allocate an empty array. Otherwise if the plan was null, patch
 				// over the API response to pretend like it is null also.
-				planBinding := plan.Entries[*entry.ExternalId].AttributeValues[attributeID]
 				if planBinding.ArrayValue.IsNull() {
 					value.ArrayValue = types.ListNull(types.StringType)
 				} else if len(planBinding.ArrayValue.Elements()) == 0 {
@@ -286,73 +941,663 @@ func (r *IncidentCatalogEntriesResource) buildModel(catalogType client.CatalogTy
 				// has lost them, that means we genuinely have a problem and will need to
 				// replan/apply to fix things.
 
-				values[attributeID] = value
+				values[attributeKey] = value
 				continue
 			}
 
 			if binding.Value != nil {
-				value.Value = types.StringValue(*binding.Value.Literal)
+				literal := *binding.Value.Literal
+				if !planBinding.Value.IsNull() && !planBinding.Value.IsUnknown() {
+					equivalent, err := refs.equivalent(ctx, attributes[attributeID], planBinding.Value.ValueString(), literal)
+					if err != nil {
+						return nil, err
+					}
+					if equivalent {
+						literal = planBinding.Value.ValueString()
+					}
+				}
+
+				value.Value = types.StringValue(literal)
 			}
 			if binding.ArrayValue != nil {
-				elements := []attr.Value{}
+				elements := make([]string, 0, len(*binding.ArrayValue))
 				for _, value := range *binding.ArrayValue {
-					elements = append(elements, types.StringValue(*value.Literal))
+					elements = append(elements, *value.Literal)
 				}
 
-				value.ArrayValue = types.ListValueMust(types.StringType, elements)
+				value.ArrayValue = stringListValue(elements)
+
+				// Only keep the plan's elements if every one of them matches what we received.
+				if !planBinding.ArrayValue.IsNull() && !planBinding.ArrayValue.IsUnknown() && len(planBinding.ArrayValue.Elements()) == len(*binding.ArrayValue) {
+					allEquivalent := true
+					for idx, element := range planBinding.ArrayValue.Elements() {
+						planElement, ok := element.(types.String)
+						if !ok || planElement.IsUnknown() || planElement.IsNull() {
+							allEquivalent = false
+							break
+						}
+
+						equivalent, err := refs.equivalent(ctx, attributes[attributeID], planElement.ValueString(), *(*binding.ArrayValue)[idx].Literal)
+						if err != nil {
+							return nil, err
+						}
+						if !equivalent {
+							allEquivalent = false
+							break
+						}
+					}
+					if allEquivalent {
+						value.ArrayValue = planBinding.ArrayValue
+					}
+				}
 			}
 
-			values[attributeID] = value
+			values[attributeKey] = value
+		}
+
+		// The API doesn't preserve the order of aliases, so if we have the same set as we
+		// planned then keep the planned order to avoid a diff.
+		var aliasesValue types.List
+		if planAliases := planEntry.Aliases; !planAliases.IsNull() && !planAliases.IsUnknown() && aliasesEquivalent(stringListElements(planAliases), entry.Aliases) {
+			aliasesValue = planAliases
+		} else {
+			aliasesValue = stringListValue(entry.Aliases)
 		}
 
-		aliases := []attr.Value{}
-		for _, alias := range entry.Aliases {
-			aliases = append(aliases, types.StringValue(alias))
+		// When ignoring rank we keep whatever we had planned, so changes to rank made by the
+		// API don't appear as a diff.
+		rank := types.Int64Value(int64(entry.Rank))
+		if planRank := planEntry.Rank; plan.IgnoreRank.ValueBool() && !planRank.IsNull() && !planRank.IsUnknown() {
+			rank = planRank
 		}
 
 		modelEntries[*entry.ExternalId] = CatalogEntryModel{
 			ID:              types.StringValue(entry.Id),
 			Name:            types.StringValue(entry.Name),
-			Aliases:         types.ListValueMust(types.StringType, aliases),
-			Rank:            types.Int64Value(int64(entry.Rank)),
+			Aliases:         aliasesValue,
+			Rank:            rank,
 			AttributeValues: values,
 			externalID:      *entry.ExternalId,
 		}
 	}
 
+	// Everything in our entries came from the API, so we always know their hash.
+	entriesHash, _ := catalogEntriesHash(modelEntries)
+
 	return &IncidentCatalogEntriesResourceModel{
-		ID:      types.StringValue(catalogType.Id),
-		Entries: modelEntries,
-	}
+		ID:                 types.StringValue(catalogType.Id),
+		Entries:            modelEntries,
+		EntriesJSON:        plan.EntriesJSON,
+		PageSize:           types.Int64Value(plan.pageSize()),
+		CreateConcurrency:  types.Int64Value(plan.createConcurrency()),
+		DeleteConcurrency:  types.Int64Value(plan.deleteConcurrency()),
+		MaxRetries:         types.Int64Value(plan.maxRetries()),
+		IgnoreRank:         types.BoolValue(plan.IgnoreRank.ValueBool()),
+		MaxDelete:          plan.MaxDelete,
+		MaxDeleteOverride:  types.BoolValue(plan.MaxDeleteOverride.ValueBool()),
+		AuthoritativeScope: plan.AuthoritativeScope,
+		DestroyBehavior:    types.StringValue(plan.destroyBehavior()),
+		RankBy:             plan.RankBy,
+		Timeouts:           plan.Timeouts,
+
+		DeleteUnmanagedEntries:     types.BoolValue(plan.deleteUnmanagedEntries()),
+		AllowDestructiveOperations: plan.AllowDestructiveOperations,
+
+		EntriesCount:        types.Int64Value(int64(len(modelEntries))),
+		EntriesHash:         types.StringValue(entriesHash),
+		UnmanagedEntryCount: types.Int64Value(int64(len(unmanagedEntryIDs))),
+		UnmanagedEntryIDs:   types.ListValueMust(types.StringType, unmanagedEntryIDs),
+		Drift:               buildCatalogEntriesDrift(nil, nil),
+	}, nil
 }
 
-type catalogEntryModelPayload struct {
-	CatalogEntryID *string
-	Payload        client.CreateEntryRequestBody
+// pageSize, createConcurrency, deleteConcurrency and maxRetries return the configured tuning values,
+// falling back to our defaults when they're not yet known, such as just after an import.
+func (m IncidentCatalogEntriesResourceModel) pageSize() int64 {
+	return int64OrDefault(m.PageSize, catalogEntriesDefaultPageSize)
 }
 
-// buildPayloads produces a list of payloads that are used to either create or update an
+func (m IncidentCatalogEntriesResourceModel) createConcurrency() int64 {
+	return int64OrDefault(m.CreateConcurrency, catalogEntriesDefaultConcurrency)
+}
+
+func (m IncidentCatalogEntriesResourceModel) deleteConcurrency() int64 {
+	return int64OrDefault(m.DeleteConcurrency, catalogEntriesDefaultConcurrency)
+}
+
+func (m IncidentCatalogEntriesResourceModel) maxRetries() int64 {
+	return int64OrDefault(m.MaxRetries, catalogEntriesDefaultMaxRetries)
+}
+
+// authoritativeScope returns the compiled authoritative_scope, or nil if we own every
+// entry in the catalog type.
+func (m IncidentCatalogEntriesResourceModel) authoritativeScope() (*regexp.Regexp, error) {
+	if m.AuthoritativeScope.IsNull() || m.AuthoritativeScope.IsUnknown() {
+		return nil, nil
+	}
+
+	scope, err := regexp.Compile(m.AuthoritativeScope.ValueString())
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing authoritative_scope")
+	}
+
+	return scope, nil
+}
+
+func (m IncidentCatalogEntriesResourceModel) deleteUnmanagedEntries() bool {
+	return m.DeleteUnmanagedEntries.IsNull() || m.DeleteUnmanagedEntries.IsUnknown() || m.DeleteUnmanagedEntries.ValueBool()
+}
+
+func (m IncidentCatalogEntriesResourceModel) destroyBehavior() string {
+	if m.DestroyBehavior.IsNull() || m.DestroyBehavior.IsUnknown() {
+		return catalogEntriesDestroyBehaviorDelete
+	}
+
+	return m.DestroyBehavior.ValueString()
+}
+
+func int64OrDefault(value types.Int64, defaultValue int64) int64 {
+	if value.IsNull() || value.IsUnknown() {
+		return defaultValue
+	}
+
+	return value.ValueInt64()
+}
+
+type catalogEntryModelPayload struct {
+	CatalogEntryID *string
+	Payload        client.CreateEntryRequestBody
+}
+
+// plannedCatalogEntries loads the entries at entriesPath from a plan, returning false if
+// they can't be known until apply, such as when they depend on resources that are yet to
+// be created.
+func plannedCatalogEntries(ctx context.Context, plan tfsdk.Plan, entriesPath path.Path, diags *diag.Diagnostics) (map[string]CatalogEntryModel, bool) {
+	var entriesValue types.Map
+	diags.Append(plan.GetAttribute(ctx, entriesPath, &entriesValue)...)
+	if diags.HasError() || entriesValue.IsNull() || entriesValue.IsUnknown() {
+		return nil, false
+	}
+
+	entries := map[string]CatalogEntryModel{}
+	if entryDiags := entriesValue.ElementsAs(ctx, &entries, false); entryDiags.HasError() {
+		// This happens when part of an entry is unknown, in which case we'll wait for the plan
+		// that happens at apply to validate it.
+		return nil, false
+	}
+
+	return entries, true
+}
+
+// rankCatalogEntries sets the rank of every entry from its position when sorted by the
+// rank_by key, which is either the entry name, external ID or the value of an attribute.
+func rankCatalogEntries(catalogType client.CatalogTypeV2, entries map[string]CatalogEntryModel, rankBy string) error {
+	var sortKey func(externalID string, entry CatalogEntryModel) string
+	switch rankBy {
+	case "name":
+		sortKey = func(_ string, entry CatalogEntryModel) string {
+			return entry.Name.ValueString()
+		}
+	case "external_id":
+		sortKey = func(externalID string, _ CatalogEntryModel) string {
+			return externalID
+		}
+	default:
+		attributeIDs := buildAttributeIDLookup(catalogType)
+		attributeID, ok := attributeIDs[rankBy]
+		if !ok {
+			return fmt.Errorf("%q is not name, external_id or an attribute of catalog type %q", rankBy, catalogType.Name)
+		}
+
+		sortKey = func(_ string, entry CatalogEntryModel) string {
+			for attributeKey, value := range entry.AttributeValues {
+				if attributeIDs[attributeKey] == attributeID {
+					return value.Value.ValueString()
+				}
+			}
+
+			return ""
+		}
+	}
+
+	externalIDs := lo.Keys(entries)
+	sort.SliceStable(externalIDs, func(i, j int) bool {
+		left, right := sortKey(externalIDs[i], entries[externalIDs[i]]), sortKey(externalIDs[j], entries[externalIDs[j]])
+		if left == right {
+			return externalIDs[i] < externalIDs[j]
+		}
+
+		leftNumber, leftErr := strconv.ParseFloat(left, 64)
+		rightNumber, rightErr := strconv.ParseFloat(right, 64)
+		if leftErr == nil && rightErr == nil && leftNumber != rightNumber {
+			return leftNumber < rightNumber
+		}
+
+		return left < right
+	})
+
+	for idx, externalID := range externalIDs {
+		entry := entries[externalID]
+		entry.Rank = types.Int64Value(int64(idx + 1))
+		entries[externalID] = entry
+	}
+
+	return nil
+}
+
+// summariseCatalogEntryChanges describes how many entries will be created, updated and
+// deleted when moving from our current state to the planned entries, or returns an empty
+// string if nothing will change.
+//
+// This is based only on the entries in state: any entries without an external ID that
+// were created outside of terraform will also be deleted, but we can't know about them
+// without listing the catalog type.
+func summariseCatalogEntryChanges(state *IncidentCatalogEntriesResourceModel, entries map[string]CatalogEntryModel) string {
+	current := map[string]CatalogEntryModel{}
+	if state != nil {
+		current = state.Entries
+	}
+
+	var toCreate, toUpdate, toDelete int
+	for externalID, entry := range entries {
+		existing, ok := current[externalID]
+		if !ok {
+			toCreate++
+		} else if catalogEntryChanged(existing, entry) {
+			toUpdate++
+		}
+	}
+	for externalID := range current {
+		if _, ok := entries[externalID]; !ok {
+			toDelete++
+		}
+	}
+
+	if toCreate == 0 && toUpdate == 0 && toDelete == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%d catalog entries will be created, %d updated and %d deleted.", toCreate, toUpdate, toDelete)
+}
+
+// buildCatalogEntriesDrift compares the entries we had in state before a refresh with those
+// we found, reporting which were changed, removed or added outside of terraform.
+func buildCatalogEntriesDrift(prior, current map[string]CatalogEntryModel) types.Object {
+	var changed, missing, added []string
+	for externalID, priorEntry := range prior {
+		currentEntry, ok := current[externalID]
+		if !ok {
+			missing = append(missing, externalID)
+		} else if catalogEntryChanged(priorEntry, currentEntry) {
+			changed = append(changed, externalID)
+		}
+	}
+	for externalID := range current {
+		if _, ok := prior[externalID]; !ok {
+			added = append(added, externalID)
+		}
+	}
+
+	toList := func(externalIDs []string) types.List {
+		sort.Strings(externalIDs)
+		elements := []attr.Value{}
+		for _, externalID := range externalIDs {
+			elements = append(elements, types.StringValue(externalID))
+		}
+
+		return types.ListValueMust(types.StringType, elements)
+	}
+
+	return types.ObjectValueMust(catalogEntriesDriftAttributeTypes, map[string]attr.Value{
+		"changed_external_ids": toList(changed),
+		"missing_external_ids": toList(missing),
+		"added_external_ids":   toList(added),
+	})
+}
+
+// catalogEntriesHash returns a hash of the content of the entries, which doesn't depend on
+// the order they were configured in. The IDs assigned by the API are left out so the hash
+// can be worked out when planning, but it returns false if any of the rest of the content
+// isn't yet known.
+//
+// We hash a refresh's worth of entries every plan, so we write each value prefixed by its
+// length rather than encoding the entries as JSON, which is several times slower.
+func catalogEntriesHash(entries map[string]CatalogEntryModel) (string, bool) {
+	externalIDs := lo.Keys(entries)
+	sort.Strings(externalIDs)
+
+	hash := sha256.New()
+	buf := make([]byte, 0, 256)
+	writeString := func(value string) {
+		buf = binary.AppendUvarint(buf, uint64(len(value)))
+		buf = append(buf, value...)
+	}
+	writeList := func(elements *[]string) {
+		// Null lists are distinct from empty ones, as the API treats them differently.
+		if elements == nil {
+			buf = append(buf, 0)
+			return
+		}
+		buf = append(buf, 1)
+		buf = binary.AppendUvarint(buf, uint64(len(*elements)))
+		for _, element := range *elements {
+			writeString(element)
+		}
+	}
+
+	for _, externalID := range externalIDs {
+		entry := entries[externalID]
+		if entry.Name.IsUnknown() || entry.Rank.IsUnknown() {
+			return "", false
+		}
+
+		aliases, ok := knownStringListElements(entry.Aliases)
+		if !ok {
+			return "", false
+		}
+
+		buf = buf[:0]
+		writeString(externalID)
+		writeString(entry.Name.ValueString())
+		writeList(aliases)
+		buf = binary.AppendVarint(buf, entry.Rank.ValueInt64())
+
+		attributeKeys := lo.Keys(entry.AttributeValues)
+		sort.Strings(attributeKeys)
+		buf = binary.AppendUvarint(buf, uint64(len(attributeKeys)))
+		for _, attributeKey := range attributeKeys {
+			binding := entry.AttributeValues[attributeKey]
+			if binding.Value.IsUnknown() {
+				return "", false
+			}
+			arrayValue, ok := knownStringListElements(binding.ArrayValue)
+			if !ok {
+				return "", false
+			}
+
+			writeString(attributeKey)
+			if binding.Value.IsNull() {
+				buf = append(buf, 0)
+			} else {
+				buf = append(buf, 1)
+				writeString(binding.Value.ValueString())
+			}
+			writeList(arrayValue)
+		}
+
+		hash.Write(buf)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), true
+}
+
+// knownStringListElements returns the elements of a list of strings, or nil if the list
+// is null. It returns false if the list or any of its elements aren't yet known.
+func knownStringListElements(list types.List) (*[]string, bool) {
+	if list.IsUnknown() {
+		return nil, false
+	}
+	if list.IsNull() {
+		return nil, true
+	}
+
+	elements := make([]string, 0, len(list.Elements()))
+	for _, element := range list.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsUnknown() {
+			return nil, false
+		}
+		elements = append(elements, value.ValueString())
+	}
+
+	return &elements, true
+}
+
+// catalogEntryChanged returns true if the planned entry differs from the current one in
+// anything that we'd send to the API.
+func catalogEntryChanged(current, planned CatalogEntryModel) bool {
+	if !current.Name.Equal(planned.Name) || !current.Aliases.Equal(planned.Aliases) || !current.Rank.Equal(planned.Rank) {
+		return true
+	}
+	if len(current.AttributeValues) != len(planned.AttributeValues) {
+		return true
+	}
+	for attributeKey, plannedValue := range planned.AttributeValues {
+		currentValue, ok := current.AttributeValues[attributeKey]
+		if !ok || !currentValue.Value.Equal(plannedValue.Value) || !currentValue.ArrayValue.Equal(plannedValue.ArrayValue) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateCatalogEntries checks that every attribute value in our entries refers to an
+// attribute that exists in the catalog type, and is of the right shape and type for it.
+// Errors are reported against entriesPath, the map of entries keyed by external ID.
+//
+// The API has no concept of required attributes, so there is nothing to check there.
+func validateCatalogEntries(catalogType client.CatalogTypeV2, entriesPath path.Path, entries map[string]CatalogEntryModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if len(entries) > catalogEntriesMaxEntries {
+		diags.AddAttributeError(entriesPath, "Too many catalog entries",
+			fmt.Sprintf("%d entries are configured, but the API supports at most %d entries per catalog type.", len(entries), catalogEntriesMaxEntries))
+	}
+
+	attributeIDs := buildAttributeIDLookup(catalogType)
+	attributes := lo.KeyBy(catalogType.Schema.Attributes, func(attribute client.CatalogTypeAttributeV2) string {
+		return attribute.Id
+	})
+
+	externalIDs := lo.Keys(entries)
+	sort.Strings(externalIDs)
+	for _, externalID := range externalIDs {
+		// Rank is sent as a 32-bit integer, so anything larger would be silently truncated.
+		if rank := entries[externalID].Rank; !rank.IsNull() && !rank.IsUnknown() {
+			if rank.ValueInt64() < math.MinInt32 || rank.ValueInt64() > math.MaxInt32 {
+				diags.AddAttributeError(entriesPath.AtMapKey(externalID).AtName("rank"), "Invalid catalog entry rank",
+					fmt.Sprintf("Entry %q has a rank of %d, but rank must fit in a 32-bit integer.", externalID, rank.ValueInt64()))
+			}
+		}
+
+		attributeKeys := lo.Keys(entries[externalID].AttributeValues)
+		sort.Strings(attributeKeys)
+		for _, attributeKey := range attributeKeys {
+			value := entries[externalID].AttributeValues[attributeKey]
+			attributePath := entriesPath.AtMapKey(externalID).AtName("attribute_values").AtMapKey(attributeKey)
+
+			attributeID, ok := attributeIDs[attributeKey]
+			if !ok {
+				diags.AddAttributeError(attributePath, "Unknown catalog type attribute",
+					fmt.Sprintf("Catalog type %q has no attribute with ID or name %q.", catalogType.Name, attributeKey))
+				continue
+			}
+
+			attribute := attributes[attributeID]
+			if !value.Value.IsNull() && !value.ArrayValue.IsNull() {
+				diags.AddAttributeError(attributePath, "Invalid catalog attribute value",
+					fmt.Sprintf("Only one of value or array_value can be set for attribute %q.", attribute.Name))
+			} else if attribute.Array && !value.Value.IsNull() {
+				diags.AddAttributeError(attributePath.AtName("value"), "Invalid catalog attribute value",
+					fmt.Sprintf("Attribute %q is an array, so must be set using array_value.", attribute.Name))
+			} else if !attribute.Array && !value.ArrayValue.IsNull() {
+				diags.AddAttributeError(attributePath.AtName("array_value"), "Invalid catalog attribute value",
+					fmt.Sprintf("Attribute %q is not an array, so must be set using value.", attribute.Name))
+			}
+
+			if !value.Value.IsNull() && !value.Value.IsUnknown() {
+				if _, err := normalizeCatalogEntryValue(attribute, value.Value.ValueString()); err != nil {
+					diags.AddAttributeError(attributePath.AtName("value"), "Invalid catalog attribute value",
+						fmt.Sprintf("Attribute %q is of type %s: %s.", attribute.Name, attribute.Type, err))
+				}
+			}
+			if !value.ArrayValue.IsNull() && !value.ArrayValue.IsUnknown() {
+				for idx, element := range value.ArrayValue.Elements() {
+					elementString, ok := element.(types.String)
+					if !ok || elementString.IsNull() || elementString.IsUnknown() {
+						continue
+					}
+					if _, err := normalizeCatalogEntryValue(attribute, elementString.ValueString()); err != nil {
+						diags.AddAttributeError(attributePath.AtName("array_value").AtListIndex(idx), "Invalid catalog attribute value",
+							fmt.Sprintf("Attribute %q is of type %s: %s.", attribute.Name, attribute.Type, err))
+					}
+				}
+			}
+		}
+	}
+
+	return diags
+}
+
+// validateCatalogEntriesUnique checks that no two entries share a name or an alias, which
+// the API would otherwise reject part way through an apply.
+func validateCatalogEntriesUnique(ctx context.Context, entriesPath path.Path, entries map[string]CatalogEntryModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var (
+		externalIDsByName  = map[string]string{}
+		externalIDsByAlias = map[string]string{}
+	)
+
+	externalIDs := lo.Keys(entries)
+	sort.Strings(externalIDs)
+	for _, externalID := range externalIDs {
+		entry := entries[externalID]
+		entryPath := entriesPath.AtMapKey(externalID)
+
+		if !entry.Name.IsUnknown() && !entry.Name.IsNull() {
+			name := entry.Name.ValueString()
+			if existing, ok := externalIDsByName[name]; ok {
+				diags.AddAttributeError(entryPath.AtName("name"), "Duplicate catalog entry name",
+					fmt.Sprintf("Entries %q and %q both have the name %q, but names must be unique within a catalog type.", existing, externalID, name))
+			} else {
+				externalIDsByName[name] = externalID
+			}
+		}
+
+		if entry.Aliases.IsUnknown() || entry.Aliases.IsNull() {
+			continue
+		}
+
+		aliases := []types.String{}
+		if aliasDiags := entry.Aliases.ElementsAs(ctx, &aliases, false); aliasDiags.HasError() {
+			continue
+		}
+		for _, alias := range lo.Uniq(aliases) {
+			if alias.IsUnknown() || alias.IsNull() {
+				continue
+			}
+			if existing, ok := externalIDsByAlias[alias.ValueString()]; ok {
+				diags.AddAttributeError(entryPath.AtName("aliases"), "Duplicate catalog entry alias",
+					fmt.Sprintf("Entries %q and %q both have the alias %q, but aliases must be unique within a catalog type.", existing, externalID, alias.ValueString()))
+			} else {
+				externalIDsByAlias[alias.ValueString()] = externalID
+			}
+		}
+	}
+
+	return diags
+}
+
+// aliasesEquivalent returns true if both lists contain the same aliases, regardless of
+// their order.
+func aliasesEquivalent(left, right []string) bool {
+	missing, extra := lo.Difference(left, right)
+	return len(missing) == 0 && len(extra) == 0
+}
+
+// checkDestructiveOperation returns an error if the provider is protecting against
+// destructive operations and we're about to delete every entry in our catalog type,
+// unless this resource has opted out.
+func (r *IncidentCatalogEntriesResource) checkDestructiveOperation(data *IncidentCatalogEntriesResourceModel, deleting int) error {
+	if !r.protectDestructiveOperations || data.AllowDestructiveOperations.ValueBool() || deleting == 0 {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"refusing to delete all %d entries in catalog type id=%s, as the provider sets protect_destructive_operations: if this is intended, set allow_destructive_operations = true on this resource",
+		deleting, data.ID.ValueString())
+}
+
+// parseMaxDelete parses a max_delete value, which is either a count or a percentage of
+// the total number of entries, into the maximum number of entries we can delete.
+func parseMaxDelete(value string, total int) (int, error) {
+	if percentage, ok := strings.CutSuffix(value, "%"); ok {
+		percent, err := strconv.ParseFloat(percentage, 64)
+		if err != nil || percent < 0 || percent > 100 {
+			return 0, fmt.Errorf("%q must be a percentage between 0%% and 100%%", value)
+		}
+
+		return int(math.Floor(float64(total) * percent / 100)), nil
+	}
+
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 0 {
+		return 0, fmt.Errorf("%q must be either a non-negative count like 100 or a percentage like 10%%", value)
+	}
+
+	return count, nil
+}
+
+// buildAttributeIDLookup returns a map from any key that can be used to reference an
+// attribute in attribute_values, either its ID or its name, to the attribute ID.
+func buildAttributeIDLookup(catalogType client.CatalogTypeV2) map[string]string {
+	attributeIDs := map[string]string{}
+	for _, attribute := range catalogType.Schema.Attributes {
+		attributeIDs[attribute.Name] = attribute.Id
+	}
+	// IDs take precedence over names, should an attribute be named after another's ID.
+	for _, attribute := range catalogType.Schema.Attributes {
+		attributeIDs[attribute.Id] = attribute.Id
+	}
+
+	return attributeIDs
+}
+
+// buildPayloads produces a list of payloads that are used to either create or update an
 // entry depending on whether we're already tracking it in our model.
-func (m IncidentCatalogEntriesResourceModel) buildPayloads(ctx context.Context) []*catalogEntryModelPayload {
-	payloads := []*catalogEntryModelPayload{}
+//
+// Values are normalized into the canonical form for their attribute type, such as
+// booleans and numbers, before being sent.
+func (m IncidentCatalogEntriesResourceModel) buildPayloads(ctx context.Context, catalogType client.CatalogTypeV2) ([]*catalogEntryModelPayload, error) {
+	attributeIDs := buildAttributeIDLookup(catalogType)
+	attributes := lo.KeyBy(catalogType.Schema.Attributes, func(attribute client.CatalogTypeAttributeV2) string {
+		return attribute.Id
+	})
+
+	payloads := make([]*catalogEntryModelPayload, 0, len(m.Entries))
 	for externalID, entry := range m.Entries {
-		values := map[string]client.EngineParamBindingPayloadV2{}
-		for attributeID, attributeValue := range entry.AttributeValues {
+		values := make(map[string]client.EngineParamBindingPayloadV2, len(entry.AttributeValues))
+		for attributeKey, attributeValue := range entry.AttributeValues {
+			attributeID, ok := attributeIDs[attributeKey]
+			if !ok {
+				return nil, fmt.Errorf("catalog entry with external_id=%s has a value for attribute %q, which does not exist in catalog type id=%s", externalID, attributeKey, catalogType.Id)
+			}
+
 			payload := client.EngineParamBindingPayloadV2{}
 			if !attributeValue.Value.IsNull() {
+				literal, err := normalizeCatalogEntryValue(attributes[attributeID], attributeValue.Value.ValueString())
+				if err != nil {
+					return nil, errors.Wrap(err, fmt.Sprintf("catalog entry with external_id=%s has an invalid value for attribute %q", externalID, attributeKey))
+				}
 				payload.Value = &client.EngineParamBindingValuePayloadV2{
-					Literal: lo.ToPtr(attributeValue.Value.ValueString()),
+					Literal: lo.ToPtr(literal),
 				}
 			}
 			if !attributeValue.ArrayValue.IsNull() {
-				arrayValue := []client.EngineParamBindingValuePayloadV2{}
+				arrayValue := make([]client.EngineParamBindingValuePayloadV2, 0, len(attributeValue.ArrayValue.Elements()))
 				for _, element := range attributeValue.ArrayValue.Elements() {
 					elementString, ok := element.(types.String)
 					if !ok {
 						panic(fmt.Sprintf("element should have been types.String but was %T", element))
 					}
+					literal, err := normalizeCatalogEntryValue(attributes[attributeID], elementString.ValueString())
+					if err != nil {
+						return nil, errors.Wrap(err, fmt.Sprintf("catalog entry with external_id=%s has an invalid value for attribute %q", externalID, attributeKey))
+					}
 					arrayValue = append(arrayValue, client.EngineParamBindingValuePayloadV2{
-						Literal: lo.ToPtr(elementString.ValueString()),
+						Literal: lo.ToPtr(literal),
 					})
 				}
 
@@ -363,10 +1608,8 @@ func (m IncidentCatalogEntriesResourceModel) buildPayloads(ctx context.Context)
 		}
 
 		aliases := []string{}
-		if !entry.Aliases.IsUnknown() {
-			if diags := entry.Aliases.ElementsAs(ctx, &aliases, false); diags.HasError() {
-				panic(spew.Sdump(diags.Errors()))
-			}
+		if !entry.Aliases.IsUnknown() && !entry.Aliases.IsNull() {
+			aliases = stringListElements(entry.Aliases)
 		}
 		payload := &catalogEntryModelPayload{
 			Payload: client.CreateEntryRequestBody{
@@ -381,41 +1624,145 @@ func (m IncidentCatalogEntriesResourceModel) buildPayloads(ctx context.Context)
 		if !entry.ID.IsUnknown() {
 			payload.CatalogEntryID = lo.ToPtr(entry.ID.ValueString())
 		}
-		if !entry.Rank.IsUnknown() && !entry.Rank.IsNull() {
+		if !entry.Rank.IsUnknown() && !entry.Rank.IsNull() && !m.IgnoreRank.ValueBool() {
 			payload.Payload.Rank = lo.ToPtr(int32(entry.Rank.ValueInt64()))
 		}
 
 		payloads = append(payloads, payload)
 	}
 
-	return payloads
+	return payloads, nil
 }
 
-func (r *IncidentCatalogEntriesResource) getEntries(ctx context.Context, catalogTypeID string) (catalogType *client.CatalogTypeV2, entries []client.CatalogEntryV2, err error) {
-	var (
-		after *string
-	)
+// resolveReferences rewrites the values of any attributes that point at other catalog
+// entries into the IDs of those entries, which is what the API expects.
+func (r *IncidentCatalogEntriesResource) resolveReferences(ctx context.Context, catalogType client.CatalogTypeV2, payloads []*catalogEntryModelPayload, refs *catalogEntryReferences) error {
+	if len(payloads) == 0 {
+		return nil
+	}
+
+	for _, attribute := range catalogType.Schema.Attributes {
+		referencedType, err := refs.catalogTypeFor(ctx, attribute)
+		if err != nil {
+			return err
+		}
+		if referencedType == nil {
+			continue
+		}
+
+		resolve := func(value *client.EngineParamBindingValuePayloadV2) error {
+			if value == nil || value.Literal == nil {
+				return nil
+			}
+
+			entryID, err := refs.resolve(ctx, referencedType.Id, *value.Literal)
+			if err != nil {
+				return err
+			}
+
+			value.Literal = lo.ToPtr(entryID)
+			return nil
+		}
 
-	for {
+		for _, payload := range payloads {
+			binding, ok := payload.Payload.AttributeValues[attribute.Id]
+			if !ok {
+				continue
+			}
+
+			if err := resolve(binding.Value); err != nil {
+				return err
+			}
+			if binding.ArrayValue != nil {
+				for idx := range *binding.ArrayValue {
+					if err := resolve(&(*binding.ArrayValue)[idx]); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *IncidentCatalogEntriesResource) getEntries(ctx context.Context, catalogTypeID string, pageSize int64) (catalogType *client.CatalogTypeV2, entries []client.CatalogEntryV2, err error) {
+	entries = []client.CatalogEntryV2{}
+	err = r.eachEntriesPage(ctx, catalogTypeID, pageSize, func(pageCatalogType *client.CatalogTypeV2, page []client.CatalogEntryV2) error {
+		catalogType = pageCatalogType
+		entries = append(entries, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return catalogType, entries, nil
+}
+
+// eachEntriesPage loads every entry of a catalog type, passing each page to the callback
+// as it arrives along with the catalog type, so that callers who only need some of the
+// entries don't have to hold all of them in memory at once.
+func (r *IncidentCatalogEntriesResource) eachEntriesPage(ctx context.Context, catalogTypeID string, pageSize int64, each func(catalogType *client.CatalogTypeV2, entries []client.CatalogEntryV2) error) error {
+	var catalogType *client.CatalogTypeV2
+	err := client.Paginate(ctx, func(ctx context.Context, after *string) (*client.Page[client.CatalogEntryV2], error) {
 		result, err := r.client.CatalogV2ListEntriesWithResponse(ctx, &client.CatalogV2ListEntriesParams{
 			CatalogTypeId: catalogTypeID,
-			PageSize:      lo.ToPtr(int64(250)),
+			PageSize:      lo.ToPtr(pageSize),
 			After:         after,
 		})
 		if err == nil && result.StatusCode() >= 400 {
-			err = fmt.Errorf(string(result.Body))
+			err = newAPIError(result.StatusCode(), result.Body)
 		}
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "listing entries")
+			return nil, err
 		}
 
-		entries = append(entries, result.JSON200.CatalogEntries...)
-		if count := len(result.JSON200.CatalogEntries); count == 0 {
-			return &result.JSON200.CatalogType, entries, nil // end pagination
-		} else {
-			after = lo.ToPtr(result.JSON200.CatalogEntries[count-1].Id)
+		catalogType = &result.JSON200.CatalogType
+
+		return &client.Page[client.CatalogEntryV2]{
+			Items: result.JSON200.CatalogEntries,
+			After: result.JSON200.PaginationMeta.After,
+		}, nil
+	}, func(entries []client.CatalogEntryV2) error {
+		return each(catalogType, entries)
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing entries")
+	}
+
+	return nil
+}
+
+// errEntryFound stops paginating once findEntry has found the entry it's looking for.
+var errEntryFound = errors.New("found entry")
+
+// findEntry looks for the entry of a catalog type with the given external ID, returning
+// nil if there isn't one. The API can't filter entries by external ID, so this pages
+// through them, and should only be used when we've no other way of finding an entry.
+func (r *IncidentCatalogEntriesResource) findEntry(ctx context.Context, catalogTypeID string, pageSize int64, externalID string) (*client.CatalogEntryV2, error) {
+	var found *client.CatalogEntryV2
+	err := r.eachEntriesPage(ctx, catalogTypeID, pageSize, func(_ *client.CatalogTypeV2, entries []client.CatalogEntryV2) error {
+		for _, entry := range entries {
+			if lo.FromPtr(entry.ExternalId) == externalID {
+				found = &entry
+				return errEntryFound
+			}
 		}
+
+		return nil
+	})
+	if err != nil && errors.Cause(err) != errEntryFound {
+		return nil, err
 	}
+
+	return found, nil
+}
+
+// isCreateConflict returns true if a create failed in the way we'd expect when an entry
+// with the same external ID already exists.
+func isCreateConflict(statusCode int) bool {
+	return statusCode == http.StatusConflict || statusCode == http.StatusUnprocessableEntity || statusCode == http.StatusBadRequest
 }
 
 // reconcile is a bit of a hack, in that terraform resources don't often work like this,
@@ -431,171 +1778,394 @@ func (r *IncidentCatalogEntriesResource) getEntries(ctx context.Context, catalog
 // deleting all entries for which we don't have a match in our model, essentially cleaning
 // house before starting over fresh.
 //
-// This is how we create, update and destroy this terraform resource.
-func (r *IncidentCatalogEntriesResource) reconcile(ctx context.Context, data *IncidentCatalogEntriesResourceModel) (*client.CatalogTypeV2, []client.CatalogEntryV2, error) {
-	_, entries, err := r.getEntries(ctx, data.ID.ValueString())
+// This is how we create, update and destroy this terraform resource. The entries we return
+// are those that exist once we're done, built from the responses to our changes.
+//
+// Catalogs can have tens of thousands of entries, so we match each page of entries against
+// our model as it arrives, keeping only those we manage and the IDs of those we'll delete,
+// rather than loading every entry before we start.
+func (r *IncidentCatalogEntriesResource) reconcile(ctx context.Context, data *IncidentCatalogEntriesResourceModel, refs *catalogEntryReferences) (*client.CatalogTypeV2, []client.CatalogEntryV2, error) {
+	scope, err := data.authoritativeScope()
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "listing entries")
+		return nil, nil, err
 	}
 
-	{
-		toDelete := []client.CatalogEntryV2{}
+	var (
+		catalogType *client.CatalogTypeV2
+		payloads    []*catalogEntryModelPayload
+		attributes  map[string]client.CatalogTypeAttributeV2
+
+		// payloadIndexes finds the payload for an external ID, and each payload has an entry
+		// in results, which holds the existing entry we matched it to until we're done.
+		payloadIndexes = map[string]int{}
+		results        []client.CatalogEntryV2
+		needsUpdate    []bool
+
+		toDelete = []catalogEntryToDelete{}
+		total    = 0
+	)
+
+	err = r.eachEntriesPage(ctx, data.ID.ValueString(), data.pageSize(), func(pageCatalogType *client.CatalogTypeV2, entries []client.CatalogEntryV2) error {
+		if catalogType == nil {
+			catalogType = pageCatalogType
+
+			// Build our payloads before we touch anything, so we fail before deleting entries if
+			// our model references attributes that don't exist.
+			payloads, err = data.buildPayloads(ctx, *catalogType)
+			if err != nil {
+				return errors.Wrap(err, "building payloads")
+			}
+			if err := r.resolveReferences(ctx, *catalogType, payloads, refs); err != nil {
+				return errors.Wrap(err, "resolving references to other catalog entries")
+			}
+
+			attributes = lo.KeyBy(catalogType.Schema.Attributes, func(attribute client.CatalogTypeAttributeV2) string {
+				return attribute.Id
+			})
+			for idx, payload := range payloads {
+				payloadIndexes[*payload.Payload.ExternalId] = idx
+			}
+			results = make([]client.CatalogEntryV2, len(payloads))
+			needsUpdate = make([]bool, len(payloads))
+		}
+
 	eachEntry:
 		for _, entry := range entries {
+			total++
+
 			if entry.ExternalId != nil {
-				_, ok := data.Entries[*entry.ExternalId]
-				if ok {
+				if idx, ok := payloadIndexes[*entry.ExternalId]; ok {
+					results[idx] = entry
+					needsUpdate[idx] = catalogEntryNeedsUpdate(attributes, payloads[idx].Payload, entry)
 					continue eachEntry // we know the ID and we've found a match, so skip
 				}
 			}
 
+			// When scoped, we only ever delete entries that fall within our scope.
+			if scope != nil && (entry.ExternalId == nil || !scope.MatchString(*entry.ExternalId)) {
+				continue eachEntry
+			}
+			if entry.ExternalId == nil && !data.deleteUnmanagedEntries() {
+				continue eachEntry
+			}
+
 			// We can't find this entry in our model, or it never had an external ID, which
 			// means we want to delete it.
-			toDelete = append(toDelete, entry)
+			toDelete = append(toDelete, catalogEntryToDelete{
+				ID:         entry.Id,
+				ExternalID: lo.FromPtr(entry.ExternalId),
+			})
 		}
 
-		tflog.Debug(ctx, fmt.Sprintf("found %d entries in the catalog, want to delete %d of them", len(entries), len(toDelete)))
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	{
+		tflog.Debug(ctx, fmt.Sprintf("found %d entries in the catalog, want to delete %d of them", total, len(toDelete)))
+
+		if !data.MaxDelete.IsNull() && !data.MaxDeleteOverride.ValueBool() {
+			maxDelete, err := parseMaxDelete(data.MaxDelete.ValueString(), total)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "parsing max_delete")
+			}
+			if len(toDelete) > maxDelete {
+				return nil, nil, fmt.Errorf(
+					"refusing to delete %d of the %d entries in catalog type id=%s, as max_delete=%s allows at most %d: if this is intended, set max_delete_override = true",
+					len(toDelete), total, catalogType.Id, data.MaxDelete.ValueString(), maxDelete)
+			}
+		}
+
+		if len(data.Entries) == 0 {
+			if err := r.checkDestructiveOperation(data, len(toDelete)); err != nil {
+				return nil, nil, err
+			}
+		}
 
-		g, ctx := errgroup.WithContext(ctx)
-		g.SetLimit(10)
+		// We don't use a context for this group, as we want to try every entry and report all
+		// the failures at once rather than stopping at the first.
+		g := &errgroup.Group{}
+		g.SetLimit(int(data.deleteConcurrency()))
 
+		// Within the limit of the group, we go only as fast as our rate limit allows.
+		ctx := withRateLimitThrottle(ctx, newRateLimitThrottle(data.deleteConcurrency()))
+
+		entryErrs := &catalogEntryErrors{}
 		for _, entry := range toDelete {
 			var (
 				entry = entry // avoid shadow loop variable
 			)
 			g.Go(func() error {
-				result, err := r.client.CatalogV2DestroyEntryWithResponse(ctx, entry.Id)
+				result, err := withRetries(ctx, data.maxRetries(), func() (*client.CatalogV2DestroyEntryResponse, error) {
+					return r.client.CatalogV2DestroyEntryWithResponse(ctx, entry.ID)
+				})
 				if err == nil && result.StatusCode() >= 400 {
-					err = fmt.Errorf(string(result.Body))
+					err = newAPIError(result.StatusCode(), result.Body)
 				}
 				if err != nil {
-					return errors.Wrap(err, "unable to destroy catalog entry, got error")
+					entryErrs.add(entry.ID, entry.ExternalID, errors.Wrap(err, "unable to destroy catalog entry, got error"))
+					return nil
 				}
 
-				tflog.Debug(ctx, fmt.Sprintf("destroyed catalog entry with id=%s", entry.Id))
+				tflog.Debug(ctx, fmt.Sprintf("destroyed catalog entry with id=%s", entry.ID))
 
 				return nil
 			})
 		}
 
-		if err := g.Wait(); err != nil {
+		_ = g.Wait() // errors are collected in entryErrs
+		if err := entryErrs.errOrNil(); err != nil {
 			return nil, nil, errors.Wrap(err, "destroying catalog entries")
 		}
 	}
 
-	// We only care about entries with an external ID, as we should have deleted all that
-	// didn't have one above. We also want this lookup to be fast to help when the entry
-	// list is very long.
-	entriesByExternalID := map[string]*client.CatalogEntryV2{}
-	for _, entry := range entries {
-		if entry.ExternalId == nil {
-			continue
-		}
+	// Rather than listing all entries again once we're done, we build the final list from
+	// the entries we already had and the responses to our creates and updates. Each
+	// payload writes only to its own index, so this is safe to do concurrently.
+	{
+		g := &errgroup.Group{}
+		g.SetLimit(int(data.createConcurrency()))
 
-		entriesByExternalID[*entry.ExternalId] = lo.ToPtr(entry)
-	}
+		// Within the limit of the group, we go only as fast as our rate limit allows.
+		ctx := withRateLimitThrottle(ctx, newRateLimitThrottle(data.createConcurrency()))
 
-	{
-		g, ctx := errgroup.WithContext(ctx)
-		g.SetLimit(10)
+		entryErrs := &catalogEntryErrors{}
 
 		// For everything in our model, we know we either want to create or update it.
-	eachPayload:
-		for _, payload := range data.buildPayloads(ctx) {
+		for idx, payload := range payloads {
 			var (
-				payload      = payload              // alias this for concurrent loop
-				shouldUpdate bool                   // mark this if we think we should update things
-				entry        *client.CatalogEntryV2 // existing entry
+				idx          = idx              // alias this for concurrent loop
+				payload      = payload          // alias this for concurrent loop
+				shouldUpdate = needsUpdate[idx] // whether the entry we matched has changed
+				entry        = results[idx]     // existing entry, if we matched one
 			)
 
-			entry, alreadyExists := entriesByExternalID[*payload.Payload.ExternalId]
-			if alreadyExists {
-				// If we found the entry in the list of all entries, then we need to diff it and
-				// update as appropriate.
-				if entry != nil {
-					isSame :=
-						reflect.DeepEqual(payload.Payload.Name, entry.Name) &&
-							reflect.DeepEqual(payload.Payload.Aliases, entry.Aliases) &&
-							(payload.Payload.Rank == nil || (*payload.Payload.Rank == entry.Rank))
-
-					currentBindings := map[string]client.EngineParamBindingPayloadV2{}
-					for attributeID, value := range entry.AttributeValues {
-						current := client.EngineParamBindingPayloadV2{}
-						if value.ArrayValue != nil {
-							current.ArrayValue = lo.ToPtr(lo.Map(*value.ArrayValue, func(binding client.CatalogEntryEngineParamBindingValueV2, _ int) client.EngineParamBindingValuePayloadV2 {
-								return client.EngineParamBindingValuePayloadV2{
-									Literal: binding.Literal,
-								}
-							}))
-						}
-						if value.Value != nil {
-							current.Value = &client.EngineParamBindingValuePayloadV2{
-								Literal: value.Value.Literal,
-							}
-						}
-
-						currentBindings[attributeID] = current
-					}
-
-					if isSame && reflect.DeepEqual(payload.Payload.AttributeValues, currentBindings) {
-						tflog.Debug(ctx, fmt.Sprintf("catalog entry with id=%s has not changed, not updating", entry.Id))
-						continue eachPayload
-					} else {
-						tflog.Debug(ctx, fmt.Sprintf("catalog entry with id=%s has changed, scheduling for update", entry.Id))
-						shouldUpdate = true
-					}
-				}
+			if entry.Id != "" && !shouldUpdate {
+				tflog.Debug(ctx, fmt.Sprintf("catalog entry with id=%s has not changed, not updating", entry.Id))
+				continue
+			}
+			if shouldUpdate {
+				tflog.Debug(ctx, fmt.Sprintf("catalog entry with id=%s has changed, scheduling for update", entry.Id))
 			}
 
 			g.Go(func() error {
 				if shouldUpdate {
-					result, err := r.client.CatalogV2UpdateEntryWithResponse(ctx, entry.Id, client.UpdateEntryRequestBody{
-						Name:            payload.Payload.Name,
-						ExternalId:      payload.Payload.ExternalId,
-						Rank:            payload.Payload.Rank,
-						Aliases:         payload.Payload.Aliases,
-						AttributeValues: payload.Payload.AttributeValues,
+					result, err := withRetries(ctx, data.maxRetries(), func() (*client.CatalogV2UpdateEntryResponse, error) {
+						return r.client.CatalogV2UpdateEntryWithResponse(ctx, entry.Id, client.UpdateEntryRequestBody{
+							Name:            payload.Payload.Name,
+							ExternalId:      payload.Payload.ExternalId,
+							Rank:            payload.Payload.Rank,
+							Aliases:         payload.Payload.Aliases,
+							AttributeValues: payload.Payload.AttributeValues,
+						})
 					})
 					if err == nil && result.StatusCode() >= 400 {
-						err = fmt.Errorf(string(result.Body))
+						err = newAPIError(result.StatusCode(), result.Body)
 					}
 					if err != nil {
-						return errors.Wrap(err, fmt.Sprintf("unable to update catalog entry with id=%s, got error", entry.Id))
+						entryErrs.add(entry.Id, *payload.Payload.ExternalId, errors.Wrap(err, fmt.Sprintf("unable to update catalog entry with id=%s, got error", entry.Id)))
+						return nil
 					}
 
 					tflog.Debug(ctx, fmt.Sprintf("updated catalog entry with id=%s", entry.Id))
+					results[idx] = result.JSON200.CatalogEntry
 				} else {
-					result, err := r.client.CatalogV2CreateEntryWithResponse(ctx, client.CreateEntryRequestBody{
-						CatalogTypeId:   data.ID.ValueString(),
-						Name:            payload.Payload.Name,
-						ExternalId:      payload.Payload.ExternalId,
-						Rank:            payload.Payload.Rank,
-						Aliases:         payload.Payload.Aliases,
-						AttributeValues: payload.Payload.AttributeValues,
+					// Creates aren't idempotent, so if we lose the response to one that succeeded,
+					// retrying it fails as the external ID is taken. We note when that might have
+					// happened, so we can go and find the entry we created.
+					var lostResponse bool
+					result, err := withRetries(ctx, data.maxRetries(), func() (*client.CatalogV2CreateEntryResponse, error) {
+						result, err := r.client.CatalogV2CreateEntryWithResponse(ctx, client.CreateEntryRequestBody{
+							CatalogTypeId:   data.ID.ValueString(),
+							Name:            payload.Payload.Name,
+							ExternalId:      payload.Payload.ExternalId,
+							Rank:            payload.Payload.Rank,
+							Aliases:         payload.Payload.Aliases,
+							AttributeValues: payload.Payload.AttributeValues,
+						})
+						if err != nil {
+							lostResponse = true
+						}
+
+						return result, err
 					})
+					if err == nil && lostResponse && isCreateConflict(result.StatusCode()) {
+						existing, findErr := r.findEntry(ctx, data.ID.ValueString(), data.pageSize(), *payload.Payload.ExternalId)
+						if findErr != nil {
+							entryErrs.add("", *payload.Payload.ExternalId, errors.Wrap(findErr, "unable to find catalog entry after retrying its create, got error"))
+							return nil
+						}
+						if existing != nil {
+							tflog.Debug(ctx, fmt.Sprintf("found catalog entry with id=%s created by a request whose response was lost", existing.Id))
+							results[idx] = *existing
+							return nil
+						}
+					}
 					if err == nil && result.StatusCode() >= 400 {
-						err = fmt.Errorf(string(result.Body))
+						err = newAPIError(result.StatusCode(), result.Body)
 					}
 					if err != nil {
-						return errors.Wrap(err, fmt.Sprintf("unable to create catalog entry with external_id=%s, got error", *payload.Payload.ExternalId))
+						entryErrs.add("", *payload.Payload.ExternalId, errors.Wrap(err, fmt.Sprintf("unable to create catalog entry with external_id=%s, got error", *payload.Payload.ExternalId)))
+						return nil
 					}
 
 					tflog.Debug(ctx, fmt.Sprintf("created a catalog entry resource with id=%s", result.JSON201.CatalogEntry.Id))
+					results[idx] = result.JSON201.CatalogEntry
 				}
 
 				return nil
 			})
 		}
 
-		if err := g.Wait(); err != nil {
+		_ = g.Wait() // errors are collected in entryErrs
+		if err := entryErrs.errOrNil(); err != nil {
 			return nil, nil, errors.Wrap(err, "reconciling catalog entries")
 		}
 	}
 
-	catalogType, entries, err := r.getEntries(ctx, data.ID.ValueString())
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "listing entries")
+	return catalogType, results, nil
+}
+
+// stringListValue builds a list of strings. Lists can't be changed once built, so every
+// empty list can share the same value.
+func stringListValue(values []string) types.List {
+	if len(values) == 0 {
+		return emptyStringList
 	}
 
-	return catalogType, entries, nil
+	elements := make([]attr.Value, len(values))
+	for idx, value := range values {
+		elements[idx] = types.StringValue(value)
+	}
+
+	return types.ListValueMust(types.StringType, elements)
+}
+
+var emptyStringList = types.ListValueMust(types.StringType, []attr.Value{})
+
+// stringListElements returns the values of a list of strings, which is much cheaper than
+// ElementsAs for the large numbers of lists in a catalog. Elements that aren't known are
+// left empty.
+func stringListElements(list types.List) []string {
+	elements := list.Elements()
+	values := make([]string, len(elements))
+	for idx, element := range elements {
+		if value, ok := element.(types.String); ok {
+			values[idx] = value.ValueString()
+		}
+	}
+
+	return values
+}
+
+// catalogEntryToDelete is all we need to keep of an entry we're going to delete.
+type catalogEntryToDelete struct {
+	ID         string
+	ExternalID string
+}
+
+// catalogEntryNeedsUpdate returns true if an existing entry differs from the payload we'd
+// send for it.
+func catalogEntryNeedsUpdate(attributes map[string]client.CatalogTypeAttributeV2, payload client.CreateEntryRequestBody, entry client.CatalogEntryV2) bool {
+	isSame :=
+		reflect.DeepEqual(payload.Name, entry.Name) &&
+			aliasesEquivalent(lo.FromPtr(payload.Aliases), entry.Aliases) &&
+			(payload.Rank == nil || (*payload.Rank == entry.Rank))
+	if !isSame {
+		return true
+	}
+
+	currentBindings := make(map[string]client.EngineParamBindingPayloadV2, len(entry.AttributeValues))
+	for attributeID, value := range entry.AttributeValues {
+		current := client.EngineParamBindingPayloadV2{}
+		if value.ArrayValue != nil {
+			current.ArrayValue = lo.ToPtr(lo.Map(*value.ArrayValue, func(binding client.CatalogEntryEngineParamBindingValueV2, _ int) client.EngineParamBindingValuePayloadV2 {
+				return client.EngineParamBindingValuePayloadV2{
+					Literal: binding.Literal,
+				}
+			}))
+		}
+		if value.Value != nil {
+			current.Value = &client.EngineParamBindingValuePayloadV2{
+				Literal: value.Value.Literal,
+			}
+		}
+
+		currentBindings[attributeID] = current
+	}
+
+	return !catalogEntryBindingsEquivalent(attributes, payload.AttributeValues, currentBindings)
+}
+
+// catalogEntryErrors collects the errors from changing many entries concurrently, so that
+// we can report every failure at once instead of only the first.
+type catalogEntryErrors struct {
+	sync.Mutex
+	errors []catalogEntryError
+}
+
+type catalogEntryError struct {
+	EntryID    string // empty if we were creating the entry
+	ExternalID string // empty if the entry has no external ID
+	Err        error
+}
+
+func (e *catalogEntryErrors) add(entryID, externalID string, err error) {
+	e.Lock()
+	defer e.Unlock()
+
+	e.errors = append(e.errors, catalogEntryError{
+		EntryID:    entryID,
+		ExternalID: externalID,
+		Err:        err,
+	})
+}
+
+// errOrNil returns the collected errors sorted by external ID, or nil if there were none.
+func (e *catalogEntryErrors) errOrNil() error {
+	e.Lock()
+	defer e.Unlock()
+
+	if len(e.errors) == 0 {
+		return nil
+	}
+
+	sort.Slice(e.errors, func(i, j int) bool {
+		if e.errors[i].ExternalID != e.errors[j].ExternalID {
+			return e.errors[i].ExternalID < e.errors[j].ExternalID
+		}
+
+		return e.errors[i].EntryID < e.errors[j].EntryID
+	})
+
+	return e
+}
+
+func (e *catalogEntryErrors) Error() string {
+	messages := lo.Map(e.errors, func(entryErr catalogEntryError, _ int) string {
+		return entryErr.Err.Error()
+	})
+
+	return fmt.Sprintf("%d catalog entries failed: %s", len(e.errors), strings.Join(messages, "; "))
+}
+
+// addReconcileError reports an error from reconcile, adding a diagnostic against each entry
+// that failed if the error came from changing individual entries. The entries path is the
+// map of entries keyed by external ID that the failed entries belong to.
+func addReconcileError(diags *diag.Diagnostics, entriesPath path.Path, err error) {
+	var entryErrs *catalogEntryErrors
+	if !errors.As(err, &entryErrs) {
+		diags.AddError("Client Error", err.Error())
+		return
+	}
+
+	for _, entryErr := range entryErrs.errors {
+		if entryErr.ExternalID != "" {
+			diags.AddAttributeError(entriesPath.AtMapKey(entryErr.ExternalID), "Client Error", entryErr.Err.Error())
+		} else {
+			diags.AddError("Client Error", entryErr.Err.Error())
+		}
+	}
 }
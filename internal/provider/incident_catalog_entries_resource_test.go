@@ -2,12 +2,28 @@ package provider
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"text/template"
+	"time"
 
 	"github.com/Masterminds/sprig"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/incident-io/terraform-provider-incident/internal/client"
+	"github.com/samber/lo"
 )
 
 func TestAccIncidentCatalogEntriesResource(t *testing.T) {
@@ -36,6 +52,10 @@ func TestAccIncidentCatalogEntriesResource(t *testing.T) {
 						"incident_catalog_entries.example", "entries.one.name", "One"),
 					resource.TestCheckResourceAttr(
 						"incident_catalog_entries.example", "entries.two.name", "Two"),
+					resource.TestCheckResourceAttr(
+						"incident_catalog_entries.example", "entries_count", "2"),
+					resource.TestCheckResourceAttrSet(
+						"incident_catalog_entries.example", "entries_hash"),
 				),
 			},
 			// Import
@@ -100,7 +120,7 @@ resource "incident_catalog_entries" "example" {
       aliases = {{ toJson .Aliases }}
 
       attribute_values = {
-        (incident_catalog_type_attribute.example_description.id) = {
+        (incident_catalog_type_attribute.example_description.name) = {
           value = {{ quote .Description }}
         }
         (incident_catalog_type_attribute.example_array.id) = {
@@ -135,3 +155,547 @@ func testAccIncidentCatalogEntriesResourceConfig(entries []catalogEntryElement)
 
 	return buf.String()
 }
+
+// TestCatalogEntriesReconcileLostCreateResponse checks that when the response to a create
+// is lost, so retrying it finds the external ID already taken, we adopt the entry that
+// was created rather than failing the apply.
+func TestCatalogEntriesReconcileLostCreateResponse(t *testing.T) {
+	api := newFakeCatalogEntriesAPI(t)
+	api.loseCreateResponses = 1
+	r, data := api.resource(1)
+
+	_, entries, err := r.reconcile(context.Background(), data, newCatalogEntryReferences(r))
+	if err != nil {
+		t.Fatalf("reconcile failed: %s", err)
+	}
+	if len(api.entries) != 1 {
+		t.Fatalf("expected 1 entry in the catalog, got %d", len(api.entries))
+	}
+	if len(entries) != 1 || entries[0].Id != api.entries[0].Id {
+		t.Errorf("expected reconcile to return the entry that was created, got %v", entries)
+	}
+}
+
+// TestCatalogEntriesReconcileReadOnly checks that a read_only provider fails on the first
+// change it would make, without retrying, and says why.
+func TestCatalogEntriesReconcileReadOnly(t *testing.T) {
+	api := newFakeCatalogEntriesAPI(t)
+	r, data := api.resource(1)
+
+	apiClient, err := client.NewClientWithResponses(api.server.URL, client.WithHTTPClient(&http.Client{
+		Transport: &readOnlyTransport{transport: http.DefaultTransport},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.client = apiClient
+
+	start := time.Now()
+	_, _, err = r.reconcile(context.Background(), data, newCatalogEntryReferences(r))
+	if err == nil {
+		t.Fatal("expected reconcile to fail")
+	}
+	if elapsed := time.Since(start); elapsed >= retryBaseDelay {
+		t.Errorf("expected reconcile to fail without retrying, took %s", elapsed)
+	}
+	if len(api.entries) != 0 {
+		t.Errorf("expected no entries to be created, got %d", len(api.entries))
+	}
+
+	var diags diag.Diagnostics
+	addReconcileError(&diags, path.Root("entries"), err)
+	if len(diags) != 1 || !strings.Contains(diags[0].Detail(), "read_only") {
+		t.Errorf("expected a diagnostic naming read_only, got %v", diags)
+	}
+}
+
+func TestParseMaxDelete(t *testing.T) {
+	testCases := []struct {
+		value     string
+		total     int
+		want      int
+		wantError bool
+	}{
+		{value: "100", total: 1000, want: 100},
+		{value: "0", total: 1000, want: 0},
+		{value: "5000", total: 10, want: 5000},
+		{value: "10%", total: 1000, want: 100},
+		{value: "10%", total: 15, want: 1},
+		{value: "0.5%", total: 1000, want: 5},
+		{value: "100%", total: 7, want: 7},
+		{value: "0%", total: 7, want: 0},
+		{value: "-1", total: 1000, wantError: true},
+		{value: "1.5", total: 1000, wantError: true},
+		{value: "ten", total: 1000, wantError: true},
+		{value: "101%", total: 1000, wantError: true},
+		{value: "-5%", total: 1000, wantError: true},
+		{value: "%", total: 1000, wantError: true},
+		{value: "10 %", total: 1000, wantError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s of %d", tc.value, tc.total), func(t *testing.T) {
+			got, err := parseMaxDelete(tc.value, tc.total)
+			if tc.wantError {
+				if err == nil {
+					t.Errorf("expected an error, got %d", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateCatalogEntriesUnique(t *testing.T) {
+	testCases := []struct {
+		name      string
+		entries   map[string]CatalogEntryModel
+		wantPaths []path.Path
+	}{
+		{
+			name: "unique names and aliases",
+			entries: map[string]CatalogEntryModel{
+				"a": testCatalogEntry("Alpha", "alpha"),
+				"b": testCatalogEntry("Beta", "beta"),
+			},
+		},
+		{
+			name: "duplicate name is reported against the later external ID",
+			entries: map[string]CatalogEntryModel{
+				"b": testCatalogEntry("Same"),
+				"a": testCatalogEntry("Same"),
+			},
+			wantPaths: []path.Path{path.Root("entries").AtMapKey("b").AtName("name")},
+		},
+		{
+			name: "duplicate alias across entries",
+			entries: map[string]CatalogEntryModel{
+				"a": testCatalogEntry("Alpha", "shared"),
+				"b": testCatalogEntry("Beta", "shared"),
+			},
+			wantPaths: []path.Path{path.Root("entries").AtMapKey("b").AtName("aliases")},
+		},
+		{
+			name: "repeated alias within one entry",
+			entries: map[string]CatalogEntryModel{
+				"a": testCatalogEntry("Alpha", "alpha", "alpha"),
+			},
+		},
+		{
+			name: "unknown names are skipped",
+			entries: map[string]CatalogEntryModel{
+				"a": {Name: types.StringUnknown(), Aliases: types.ListNull(types.StringType)},
+				"b": {Name: types.StringUnknown(), Aliases: types.ListNull(types.StringType)},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := validateCatalogEntriesUnique(context.Background(), path.Root("entries"), tc.entries)
+
+			gotPaths := []path.Path{}
+			for _, d := range diags {
+				if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+					gotPaths = append(gotPaths, withPath.Path())
+				}
+			}
+			if len(diags) != len(tc.wantPaths) || len(gotPaths) != len(tc.wantPaths) {
+				t.Fatalf("expected %d diagnostics, got %v", len(tc.wantPaths), diags)
+			}
+			for idx := range tc.wantPaths {
+				if !gotPaths[idx].Equal(tc.wantPaths[idx]) {
+					t.Errorf("expected a diagnostic at %s, got %s", tc.wantPaths[idx], gotPaths[idx])
+				}
+			}
+		})
+	}
+}
+
+func TestRankCatalogEntries(t *testing.T) {
+	catalogType := client.CatalogTypeV2{
+		Name: "Service",
+		Schema: client.CatalogTypeSchemaV2{
+			Attributes: []client.CatalogTypeAttributeV2{
+				{Id: "01HPRIORITY000000000000000", Name: "Priority", Type: "Number"},
+			},
+		},
+	}
+	withPriority := func(name, priority string) CatalogEntryModel {
+		entry := testCatalogEntry(name)
+		entry.AttributeValues = map[string]CatalogEntryAttributeBindingModel{
+			"Priority": {Value: types.StringValue(priority), ArrayValue: types.ListNull(types.StringType)},
+		}
+		return entry
+	}
+
+	testCases := []struct {
+		name      string
+		rankBy    string
+		entries   map[string]CatalogEntryModel
+		want      map[string]int64
+		wantError bool
+	}{
+		{
+			name:   "by name",
+			rankBy: "name",
+			entries: map[string]CatalogEntryModel{
+				"x": testCatalogEntry("Charlie"),
+				"y": testCatalogEntry("Alpha"),
+				"z": testCatalogEntry("Bravo"),
+			},
+			want: map[string]int64{"y": 1, "z": 2, "x": 3},
+		},
+		{
+			name:   "by external ID",
+			rankBy: "external_id",
+			entries: map[string]CatalogEntryModel{
+				"b": testCatalogEntry("Alpha"),
+				"a": testCatalogEntry("Bravo"),
+			},
+			want: map[string]int64{"a": 1, "b": 2},
+		},
+		{
+			name:   "ties are broken by external ID",
+			rankBy: "name",
+			entries: map[string]CatalogEntryModel{
+				"b": testCatalogEntry("Same"),
+				"a": testCatalogEntry("Same"),
+				"c": testCatalogEntry("Earlier"),
+			},
+			want: map[string]int64{"c": 1, "a": 2, "b": 3},
+		},
+		{
+			name:   "numeric attribute values are compared as numbers",
+			rankBy: "Priority",
+			entries: map[string]CatalogEntryModel{
+				"a": withPriority("A", "10"),
+				"b": withPriority("B", "9"),
+				"c": withPriority("C", "100"),
+			},
+			want: map[string]int64{"b": 1, "a": 2, "c": 3},
+		},
+		{
+			name:   "attribute referenced by ID",
+			rankBy: "01HPRIORITY000000000000000",
+			entries: map[string]CatalogEntryModel{
+				"a": withPriority("A", "2"),
+				"b": withPriority("B", "1"),
+			},
+			want: map[string]int64{"b": 1, "a": 2},
+		},
+		{
+			name:   "entries without the attribute sort first",
+			rankBy: "Priority",
+			entries: map[string]CatalogEntryModel{
+				"a": withPriority("A", "1"),
+				"b": testCatalogEntry("B"),
+			},
+			want: map[string]int64{"b": 1, "a": 2},
+		},
+		{
+			name:      "unknown rank_by",
+			rankBy:    "Owner",
+			entries:   map[string]CatalogEntryModel{"a": testCatalogEntry("A")},
+			wantError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := rankCatalogEntries(catalogType, tc.entries, tc.rankBy)
+			if tc.wantError {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			got := map[string]int64{}
+			for externalID, entry := range tc.entries {
+				got[externalID] = entry.Rank.ValueInt64()
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected ranks %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+// testCatalogEntry returns an entry with the given name and aliases, and no attribute
+// values.
+func testCatalogEntry(name string, aliases ...string) CatalogEntryModel {
+	aliasValues := []attr.Value{}
+	for _, alias := range aliases {
+		aliasValues = append(aliasValues, types.StringValue(alias))
+	}
+
+	return CatalogEntryModel{
+		ID:              types.StringUnknown(),
+		Name:            types.StringValue(name),
+		Aliases:         types.ListValueMust(types.StringType, aliasValues),
+		Rank:            types.Int64Null(),
+		AttributeValues: map[string]CatalogEntryAttributeBindingModel{},
+	}
+}
+
+// BenchmarkCatalogEntriesReconcile measures syncing 50k entries, which is the scale of the
+// largest service catalogs, against a fake API that serves them from memory. Run with:
+//
+//	go test ./internal/provider -run '^$' -bench CatalogEntriesReconcile -benchmem
+func BenchmarkCatalogEntriesReconcile(b *testing.B) {
+	const count = 50_000
+
+	b.Run("unchanged", func(b *testing.B) {
+		api := newFakeCatalogEntriesAPI(b)
+		api.seed(count)
+		r, data := api.resource(count)
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			benchmarkCatalogEntriesApply(b, r, data)
+		}
+	})
+
+	b.Run("create", func(b *testing.B) {
+		api := newFakeCatalogEntriesAPI(b)
+		r, data := api.resource(count)
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			api.reset()
+			b.StartTimer()
+
+			benchmarkCatalogEntriesApply(b, r, data)
+		}
+	})
+}
+
+// benchmarkCatalogEntriesApply does the work of applying the resource, in the same way as
+// Update does.
+func benchmarkCatalogEntriesApply(b *testing.B, r *IncidentCatalogEntriesResource, data *IncidentCatalogEntriesResourceModel) {
+	ctx := context.Background()
+	refs := newCatalogEntryReferences(r)
+
+	catalogType, entries, err := r.reconcile(ctx, data, refs)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if _, err := r.buildModel(ctx, *catalogType, entries, data, refs); err != nil {
+		b.Fatal(err)
+	}
+}
+
+// fakeCatalogEntriesAPI serves enough of the catalog API to reconcile entries against.
+type fakeCatalogEntriesAPI struct {
+	sync.Mutex
+	server      *httptest.Server
+	catalogType client.CatalogTypeV2
+	entries     []client.CatalogEntryV2
+
+	// loseCreateResponses is the number of creates that succeed but have their connection
+	// dropped before we respond, as if the response was lost on its way back.
+	loseCreateResponses int
+}
+
+func newFakeCatalogEntriesAPI(b testing.TB) *fakeCatalogEntriesAPI {
+	api := &fakeCatalogEntriesAPI{
+		catalogType: client.CatalogTypeV2{
+			Id:       "01HBENCHCATALOGTYPE0000000",
+			Name:     "Service",
+			TypeName: "Custom[\"Service\"]",
+			Schema: client.CatalogTypeSchemaV2{
+				Attributes: []client.CatalogTypeAttributeV2{
+					{Id: "01HBENCHATTRIBUTE000000000", Name: "Description", Type: "Text"},
+				},
+			},
+		},
+	}
+	api.server = httptest.NewServer(api)
+	b.Cleanup(api.server.Close)
+
+	return api
+}
+
+// seed fills the catalog with the entries that resource will plan.
+func (api *fakeCatalogEntriesAPI) seed(count int) {
+	for idx := 0; idx < count; idx++ {
+		externalID := fmt.Sprintf("service-%d", idx)
+		api.entries = append(api.entries, client.CatalogEntryV2{
+			Id:            fmt.Sprintf("entry-%d", idx),
+			CatalogTypeId: api.catalogType.Id,
+			ExternalId:    lo.ToPtr(externalID),
+			Name:          externalID,
+			Aliases:       []string{},
+			AttributeValues: map[string]client.CatalogEntryEngineParamBindingV2{
+				"01HBENCHATTRIBUTE000000000": {
+					Value: &client.CatalogEntryEngineParamBindingValueV2{
+						Literal: lo.ToPtr(fmt.Sprintf("Description of %s", externalID)),
+					},
+				},
+			},
+		})
+	}
+}
+
+func (api *fakeCatalogEntriesAPI) reset() {
+	api.Lock()
+	defer api.Unlock()
+
+	api.entries = nil
+}
+
+// resource returns a resource configured to talk to the fake API, and a plan for count
+// entries.
+func (api *fakeCatalogEntriesAPI) resource(count int) (*IncidentCatalogEntriesResource, *IncidentCatalogEntriesResourceModel) {
+	apiClient, err := client.NewClientWithResponses(api.server.URL)
+	if err != nil {
+		panic(err)
+	}
+
+	entries := map[string]CatalogEntryModel{}
+	for idx := 0; idx < count; idx++ {
+		externalID := fmt.Sprintf("service-%d", idx)
+		entries[externalID] = CatalogEntryModel{
+			ID:      types.StringUnknown(),
+			Name:    types.StringValue(externalID),
+			Aliases: types.ListValueMust(types.StringType, nil),
+			Rank:    types.Int64Unknown(),
+			AttributeValues: map[string]CatalogEntryAttributeBindingModel{
+				"Description": {
+					Value:      types.StringValue(fmt.Sprintf("Description of %s", externalID)),
+					ArrayValue: types.ListNull(types.StringType),
+				},
+			},
+		}
+	}
+
+	return &IncidentCatalogEntriesResource{client: apiClient}, &IncidentCatalogEntriesResourceModel{
+		ID:      types.StringValue(api.catalogType.Id),
+		Entries: entries,
+	}
+}
+
+func (api *fakeCatalogEntriesAPI) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	api.Lock()
+	defer api.Unlock()
+
+	switch {
+	case req.Method == http.MethodGet && req.URL.Path == "/v2/catalog_types":
+		api.respond(w, http.StatusOK, client.ListTypesResponseBody{
+			CatalogTypes: []client.CatalogTypeV2{api.catalogType},
+		})
+
+	case req.Method == http.MethodGet && req.URL.Path == "/v2/catalog_entries":
+		offset, _ := strconv.Atoi(req.URL.Query().Get("after"))
+		pageSize, _ := strconv.Atoi(req.URL.Query().Get("page_size"))
+		end := offset + pageSize
+		if end > len(api.entries) {
+			end = len(api.entries)
+		}
+
+		body := client.ListEntriesResponseBody{
+			CatalogType:    api.catalogType,
+			CatalogEntries: api.entries[offset:end],
+			PaginationMeta: client.PaginationMetaResult{PageSize: int64(pageSize)},
+		}
+		if end < len(api.entries) {
+			body.PaginationMeta.After = lo.ToPtr(strconv.Itoa(end))
+		}
+		api.respond(w, http.StatusOK, body)
+
+	case req.Method == http.MethodPost && req.URL.Path == "/v2/catalog_entries":
+		var payload client.CreateEntryRequestBody
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			api.respond(w, http.StatusBadRequest, nil)
+			return
+		}
+
+		for _, existing := range api.entries {
+			if lo.FromPtr(existing.ExternalId) == lo.FromPtr(payload.ExternalId) {
+				api.respond(w, http.StatusUnprocessableEntity, nil)
+				return
+			}
+		}
+
+		entry := client.CatalogEntryV2{
+			Id:              fmt.Sprintf("entry-%d", len(api.entries)),
+			CatalogTypeId:   payload.CatalogTypeId,
+			ExternalId:      payload.ExternalId,
+			Name:            payload.Name,
+			Aliases:         lo.FromPtr(payload.Aliases),
+			AttributeValues: map[string]client.CatalogEntryEngineParamBindingV2{},
+		}
+		for attributeID, binding := range payload.AttributeValues {
+			if binding.Value != nil {
+				entry.AttributeValues[attributeID] = client.CatalogEntryEngineParamBindingV2{
+					Value: &client.CatalogEntryEngineParamBindingValueV2{Literal: binding.Value.Literal},
+				}
+			}
+		}
+		api.entries = append(api.entries, entry)
+		if api.loseCreateResponses > 0 {
+			api.loseCreateResponses--
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		api.respond(w, http.StatusCreated, client.CreateEntryResponseBody{CatalogEntry: entry})
+
+	default:
+		api.respond(w, http.StatusNotFound, nil)
+	}
+}
+
+func (api *fakeCatalogEntriesAPI) respond(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// BenchmarkCatalogEntriesConversion measures converting 10k entries between the API and
+// our model, which dominates refreshing a large catalog type.
+func BenchmarkCatalogEntriesConversion(b *testing.B) {
+	const count = 10_000
+
+	api := newFakeCatalogEntriesAPI(b)
+	api.seed(count)
+	r, data := api.resource(count)
+
+	b.Run("buildModel", func(b *testing.B) {
+		ctx := context.Background()
+		refs := newCatalogEntryReferences(r)
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := r.buildModel(ctx, api.catalogType, api.entries, data, refs); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("buildPayloads", func(b *testing.B) {
+		ctx := context.Background()
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := data.buildPayloads(ctx, api.catalogType); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,540 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/incident-io/terraform-provider-incident/internal/client"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+var (
+	_ resource.Resource                = &IncidentCatalogEntriesSetResource{}
+	_ resource.ResourceWithImportState = &IncidentCatalogEntriesSetResource{}
+	_ resource.ResourceWithModifyPlan  = &IncidentCatalogEntriesSetResource{}
+)
+
+// IncidentCatalogEntriesSetResource manages the entries of several catalog types at once,
+// delegating to IncidentCatalogEntriesResource to reconcile each of them.
+type IncidentCatalogEntriesSetResource struct {
+	client   *client.ClientWithResponses
+	apiUsage *apiUsage
+	entries  *IncidentCatalogEntriesResource
+}
+
+type IncidentCatalogEntriesSetResourceModel struct {
+	ID                         types.String                          `tfsdk:"id"`
+	CatalogTypes               map[string]CatalogEntriesSetTypeModel `tfsdk:"catalog_types"`
+	AllowDestructiveOperations types.Bool                            `tfsdk:"allow_destructive_operations"`
+	Timeouts                   timeouts.Value                        `tfsdk:"timeouts"`
+}
+
+type CatalogEntriesSetTypeModel struct {
+	Entries map[string]CatalogEntryModel `tfsdk:"entries"`
+}
+
+func NewIncidentCatalogEntriesSetResource() resource.Resource {
+	return &IncidentCatalogEntriesSetResource{}
+}
+
+func (r *IncidentCatalogEntriesSetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_catalog_entries_set"
+}
+
+func (r *IncidentCatalogEntriesSetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+This resource manages all entries for several catalog types at once, for when the entries
+of one type reference the entries of another.
+
+It behaves as if you had an incident_catalog_entries resource for each catalog type, and
+is similarly authoritative: it will delete _all_ entries from each catalog type that it
+doesn't manage, even those created outside of Terraform.
+
+Catalog types are reconciled in dependency order, so where an attribute of one catalog type
+references another catalog type in the set, the entries it references are created first.
+This means entries can reference entries of another type by their external ID or alias,
+even if they are being created in the same apply, without needing depends_on.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Comma separated list of the IDs of the catalog types managed by this resource.",
+				Computed:            true,
+			},
+			"catalog_types": schema.MapNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "Map of catalog type ID to the entries of that catalog type.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"entries": schema.MapNestedAttribute{
+							Required:            true,
+							MarkdownDescription: "Map of external ID to entry in the catalog.",
+							NestedObject:        catalogEntryNestedAttributeObject(),
+						},
+					},
+				},
+			},
+			"allow_destructive_operations": schema.BoolAttribute{
+				MarkdownDescription: "If true, this resource can delete every entry of a catalog type, such as when it's destroyed or a catalog type is removed from it, even when the provider sets `protect_destructive_operations`. This must be applied before the destroy is planned.",
+				Optional:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *IncidentCatalogEntriesSetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*IncidentProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.Client
+	r.apiUsage = client.APIUsage
+	r.entries = &IncidentCatalogEntriesResource{
+		client:                       client.Client,
+		protectDestructiveOperations: client.ProtectDestructiveOperations,
+		validateReferences:           client.ValidateReferences,
+	}
+}
+
+// ModifyPlan plans our ID from the catalog types we manage, and checks the entries of
+// each catalog type in the same way as IncidentCatalogEntriesResource, so that mistakes
+// and deletions the provider would refuse are caught at plan time rather than part way
+// through an apply.
+func (r *IncidentCatalogEntriesSetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_entries_set", "ModifyPlan")
+	defer done()
+
+	if r.entries == nil {
+		return
+	}
+
+	var state *IncidentCatalogEntriesSetResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Nothing to validate when we're being destroyed, other than whether we're allowed to.
+	if req.Plan.Raw.IsNull() {
+		catalogTypeIDs := lo.Keys(state.CatalogTypes)
+		sort.Strings(catalogTypeIDs)
+		for _, catalogTypeID := range catalogTypeIDs {
+			if err := r.checkDestructiveOperation(state, catalogTypeID, state.AllowDestructiveOperations); err != nil {
+				resp.Diagnostics.AddError("Destructive Operation Prevented", err.Error())
+			}
+		}
+
+		return
+	}
+
+	var (
+		catalogTypes               types.Map
+		allowDestructiveOperations types.Bool
+	)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("catalog_types"), &catalogTypes)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_destructive_operations"), &allowDestructiveOperations)...)
+	if resp.Diagnostics.HasError() || catalogTypes.IsUnknown() {
+		return
+	}
+
+	catalogTypeIDs := lo.Keys(catalogTypes.Elements())
+	sort.Strings(catalogTypeIDs)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringValue(catalogEntriesSetID(catalogTypeIDs)))...)
+
+	// Catalog types we stop managing have all their entries deleted.
+	if state != nil {
+		removedCatalogTypeIDs := lo.Without(lo.Keys(state.CatalogTypes), catalogTypeIDs...)
+		sort.Strings(removedCatalogTypeIDs)
+		for _, catalogTypeID := range removedCatalogTypeIDs {
+			if err := r.checkDestructiveOperation(state, catalogTypeID, allowDestructiveOperations); err != nil {
+				resp.Diagnostics.AddError("Destructive Operation Prevented", err.Error())
+			}
+		}
+	}
+
+	for _, catalogTypeID := range catalogTypeIDs {
+		catalogTypePath := path.Root("catalog_types").AtMapKey(catalogTypeID)
+		if r.entries.validateReferences {
+			var referenceDiags diag.Diagnostics
+			validateReference(ctx, &referenceDiags, catalogTypePath, "catalog type", types.StringValue(catalogTypeID), catalogTypeExists(r.client))
+			resp.Diagnostics.Append(referenceDiags...)
+			if referenceDiags.HasError() {
+				continue
+			}
+		}
+
+		var entryDiags diag.Diagnostics
+		entries, ok := plannedCatalogEntries(ctx, resp.Plan, catalogTypePath.AtName("entries"), &entryDiags)
+		resp.Diagnostics.Append(entryDiags...)
+		if !ok {
+			continue
+		}
+
+		if len(entries) == 0 && state != nil {
+			if err := r.checkDestructiveOperation(state, catalogTypeID, allowDestructiveOperations); err != nil {
+				resp.Diagnostics.AddAttributeError(catalogTypePath.AtName("entries"), "Destructive Operation Prevented", err.Error())
+			}
+		}
+
+		resp.Diagnostics.Append(validateCatalogEntriesUnique(ctx, catalogTypePath.AtName("entries"), entries)...)
+
+		result, err := r.client.CatalogV2ShowTypeWithResponse(ctx, catalogTypeID)
+		if err == nil && result.StatusCode() >= 400 {
+			err = newAPIError(result.StatusCode(), result.Body)
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(catalogTypePath, "Client Error", fmt.Sprintf("Unable to read catalog type, got error: %s", err))
+			continue
+		}
+
+		resp.Diagnostics.Append(validateCatalogEntries(result.JSON200.CatalogType, catalogTypePath.AtName("entries"), entries)...)
+	}
+}
+
+// checkDestructiveOperation returns an error if the provider would refuse to delete every
+// entry that state has for the catalog type, as happens when it's removed from the set.
+func (r *IncidentCatalogEntriesSetResource) checkDestructiveOperation(state *IncidentCatalogEntriesSetResourceModel, catalogTypeID string, allowDestructiveOperations types.Bool) error {
+	return r.entries.checkDestructiveOperation(&IncidentCatalogEntriesResourceModel{
+		ID:                         types.StringValue(catalogTypeID),
+		AllowDestructiveOperations: allowDestructiveOperations,
+	}, len(state.CatalogTypes[catalogTypeID].Entries))
+}
+
+func (r *IncidentCatalogEntriesSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_entries_set", "Create")
+	defer done()
+
+	var data *IncidentCatalogEntriesSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, catalogEntriesDefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	data, ok := r.reconcile(ctx, data, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IncidentCatalogEntriesSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_entries_set", "Read")
+	defer done()
+
+	var data *IncidentCatalogEntriesSetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, catalogEntriesDefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// Just after an import we only know our ID, which lists the catalog types.
+	if data.CatalogTypes == nil {
+		data.CatalogTypes = map[string]CatalogEntriesSetTypeModel{}
+		for _, catalogTypeID := range strings.Split(data.ID.ValueString(), ",") {
+			data.CatalogTypes[catalogTypeID] = CatalogEntriesSetTypeModel{}
+		}
+	}
+
+	refs := newCatalogEntryReferences(r.entries)
+	for catalogTypeID, catalogType := range data.CatalogTypes {
+		model, err := r.readCatalogType(ctx, catalogTypeID, catalogType, refs)
+		if isNotFound(err) {
+			resp.Diagnostics.AddWarning("Not Found", fmt.Sprintf("Unable to find catalog type with ID %s, removing its entries from state.", catalogTypeID))
+			delete(data.CatalogTypes, catalogTypeID)
+			continue
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list entries for catalog type id=%s, got error: %s", catalogTypeID, err))
+			return
+		}
+
+		data.CatalogTypes[catalogTypeID] = CatalogEntriesSetTypeModel{
+			Entries: model.Entries,
+		}
+	}
+
+	// If every catalog type has gone, so has the set.
+	if len(data.CatalogTypes) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.StringValue(catalogEntriesSetID(lo.Keys(data.CatalogTypes)))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IncidentCatalogEntriesSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_entries_set", "Update")
+	defer done()
+
+	var data, state *IncidentCatalogEntriesSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, catalogEntriesDefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Any catalog types we no longer manage should have their entries removed, just as if
+	// their incident_catalog_entries resource had been destroyed.
+	removed := &IncidentCatalogEntriesSetResourceModel{
+		CatalogTypes:               map[string]CatalogEntriesSetTypeModel{},
+		AllowDestructiveOperations: data.AllowDestructiveOperations,
+	}
+	for catalogTypeID := range state.CatalogTypes {
+		if _, ok := data.CatalogTypes[catalogTypeID]; !ok {
+			removed.CatalogTypes[catalogTypeID] = CatalogEntriesSetTypeModel{
+				Entries: map[string]CatalogEntryModel{},
+			}
+		}
+	}
+	if len(removed.CatalogTypes) > 0 {
+		if _, ok := r.reconcile(ctx, removed, &resp.Diagnostics); !ok {
+			return
+		}
+	}
+
+	data, ok := r.reconcile(ctx, data, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IncidentCatalogEntriesSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_entries_set", "Delete")
+	defer done()
+
+	var data *IncidentCatalogEntriesSetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, catalogEntriesDefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	for catalogTypeID := range data.CatalogTypes {
+		data.CatalogTypes[catalogTypeID] = CatalogEntriesSetTypeModel{
+			Entries: map[string]CatalogEntryModel{},
+		}
+	}
+
+	r.reconcile(ctx, data, &resp.Diagnostics)
+}
+
+// ImportState accepts a comma separated list of catalog type IDs.
+func (r *IncidentCatalogEntriesSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// reconcile applies the entries for every catalog type in our model, in dependency order,
+// and returns the resulting model. Should it fail, it adds diagnostics and returns false.
+func (r *IncidentCatalogEntriesSetResource) reconcile(ctx context.Context, data *IncidentCatalogEntriesSetResourceModel, diags *diag.Diagnostics) (*IncidentCatalogEntriesSetResourceModel, bool) {
+	refs := newCatalogEntryReferences(r.entries)
+
+	catalogTypeIDs, err := r.orderCatalogTypes(ctx, lo.Keys(data.CatalogTypes), refs)
+	if err != nil {
+		diags.AddError("Client Error", err.Error())
+		return nil, false
+	}
+
+	// When deleting, we remove the entries that reference others before the entries they
+	// reference, so work in reverse.
+	deleting := lo.EveryBy(lo.Values(data.CatalogTypes), func(catalogType CatalogEntriesSetTypeModel) bool {
+		return len(catalogType.Entries) == 0
+	})
+	if deleting {
+		catalogTypeIDs = lo.Reverse(catalogTypeIDs)
+	}
+
+	result := &IncidentCatalogEntriesSetResourceModel{
+		CatalogTypes:               map[string]CatalogEntriesSetTypeModel{},
+		AllowDestructiveOperations: data.AllowDestructiveOperations,
+		Timeouts:                   data.Timeouts,
+	}
+	for _, catalogTypeID := range catalogTypeIDs {
+		tflog.Debug(ctx, fmt.Sprintf("reconciling entries for catalog type id=%s", catalogTypeID))
+
+		model := &IncidentCatalogEntriesResourceModel{
+			ID:                         types.StringValue(catalogTypeID),
+			Entries:                    data.CatalogTypes[catalogTypeID].Entries,
+			AllowDestructiveOperations: data.AllowDestructiveOperations,
+		}
+		catalogType, entries, err := r.entries.reconcile(ctx, model, refs)
+		if err != nil {
+			addReconcileError(diags, path.Root("catalog_types").AtMapKey(catalogTypeID).AtName("entries"), err)
+			return nil, false
+		}
+
+		// We've just changed these entries, so anything that references them needs to see
+		// their latest state.
+		refs.forget(catalogTypeID)
+
+		model, err = r.entries.buildModel(ctx, *catalogType, entries, model, refs)
+		if err != nil {
+			diags.AddError("Client Error", err.Error())
+			return nil, false
+		}
+
+		result.CatalogTypes[catalogTypeID] = CatalogEntriesSetTypeModel{
+			Entries: model.Entries,
+		}
+	}
+
+	result.ID = types.StringValue(catalogEntriesSetID(lo.Keys(result.CatalogTypes)))
+
+	return result, true
+}
+
+func (r *IncidentCatalogEntriesSetResource) readCatalogType(ctx context.Context, catalogTypeID string, current CatalogEntriesSetTypeModel, refs *catalogEntryReferences) (*IncidentCatalogEntriesResourceModel, error) {
+	model := &IncidentCatalogEntriesResourceModel{
+		ID:      types.StringValue(catalogTypeID),
+		Entries: current.Entries,
+	}
+
+	catalogType, entries, err := r.entries.getEntries(ctx, catalogTypeID, model.pageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	return r.entries.buildModel(ctx, *catalogType, entries, model, refs)
+}
+
+// orderCatalogTypes sorts the catalog types so that any catalog type comes after all the
+// others in the set that its attributes reference.
+func (r *IncidentCatalogEntriesSetResource) orderCatalogTypes(ctx context.Context, catalogTypeIDs []string, refs *catalogEntryReferences) ([]string, error) {
+	sort.Strings(catalogTypeIDs)
+
+	result, err := r.client.CatalogV2ListTypesWithResponse(ctx)
+	if err == nil && result.StatusCode() >= 400 {
+		err = newAPIError(result.StatusCode(), result.Body)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "listing catalog types")
+	}
+
+	catalogTypes := lo.KeyBy(result.JSON200.CatalogTypes, func(catalogType client.CatalogTypeV2) string {
+		return catalogType.Id
+	})
+
+	dependencies := map[string][]string{}
+	for _, catalogTypeID := range catalogTypeIDs {
+		catalogType, ok := catalogTypes[catalogTypeID]
+		if !ok {
+			return nil, fmt.Errorf("could not find catalog type with id=%s", catalogTypeID)
+		}
+
+		for _, attribute := range catalogType.Schema.Attributes {
+			referencedType, err := refs.catalogTypeFor(ctx, attribute)
+			if err != nil {
+				return nil, err
+			}
+			if referencedType == nil || referencedType.Id == catalogTypeID || !lo.Contains(catalogTypeIDs, referencedType.Id) {
+				continue
+			}
+
+			dependencies[catalogTypeID] = append(dependencies[catalogTypeID], referencedType.Id)
+		}
+	}
+
+	var (
+		ordered  []string
+		visited  = map[string]bool{}
+		visiting = map[string]bool{}
+		visit    func(catalogTypeID string) error
+	)
+	visit = func(catalogTypeID string) error {
+		if visited[catalogTypeID] {
+			return nil
+		}
+		if visiting[catalogTypeID] {
+			return fmt.Errorf("catalog type id=%s is part of a cycle of references between catalog types, so they can't be ordered", catalogTypeID)
+		}
+
+		visiting[catalogTypeID] = true
+		for _, dependency := range dependencies[catalogTypeID] {
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+		visiting[catalogTypeID] = false
+		visited[catalogTypeID] = true
+
+		ordered = append(ordered, catalogTypeID)
+		return nil
+	}
+
+	for _, catalogTypeID := range catalogTypeIDs {
+		if err := visit(catalogTypeID); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+// catalogEntriesSetID builds our ID from the catalog types we manage, so that it can be
+// used to import the same set of catalog types.
+func catalogEntriesSetID(catalogTypeIDs []string) string {
+	sort.Strings(catalogTypeIDs)
+
+	return strings.Join(catalogTypeIDs, ",")
+}
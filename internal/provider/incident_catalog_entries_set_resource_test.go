@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/Masterminds/sprig"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIncidentCatalogEntriesSetResource(t *testing.T) {
+	// Every step uses the same catalog types, so that later steps update the set in place.
+	id := uuid.NewString()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and read
+			{
+				Config: testAccIncidentCatalogEntriesSetResourceConfig(id, "payments"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"incident_catalog_entries_set.example", "id"),
+					resource.TestCheckResourceAttr(
+						"incident_catalog_entries_set.example", "catalog_types.%", "2"),
+				),
+			},
+			// Import
+			{
+				ResourceName:      "incident_catalog_entries_set.example",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update the team that owns the service
+			{
+				Config: testAccIncidentCatalogEntriesSetResourceConfig(id, "platform"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"incident_catalog_entries_set.example", "id"),
+				),
+			},
+		},
+	})
+}
+
+var catalogEntriesSetTemplate = template.Must(template.New("incident_catalog_entries_set").Funcs(sprig.TxtFuncMap()).Parse(`
+resource "incident_catalog_type" "team" {
+  name        = "Catalog Entries Set Team Acceptance Test ({{ .ID }})"
+  description = "Used in terraform acceptance tests for incident_catalog_entries_set"
+}
+
+resource "incident_catalog_type" "service" {
+  name        = "Catalog Entries Set Service Acceptance Test ({{ .ID }})"
+  description = "Used in terraform acceptance tests for incident_catalog_entries_set"
+}
+
+resource "incident_catalog_type_attribute" "service_owner" {
+  catalog_type_id = incident_catalog_type.service.id
+
+  name = "Owner"
+  type = incident_catalog_type.team.type_name
+}
+
+resource "incident_catalog_entries_set" "example" {
+  catalog_types = {
+    (incident_catalog_type.team.id) = {
+      entries = {
+        "payments" = {
+          name             = "Payments"
+          attribute_values = {}
+        }
+        "platform" = {
+          name             = "Platform"
+          attribute_values = {}
+        }
+      }
+    }
+
+    (incident_catalog_type.service.id) = {
+      entries = {
+        "payments-api" = {
+          name = "Payments API"
+
+          attribute_values = {
+            (incident_catalog_type_attribute.service_owner.id) = {
+              value = {{ quote .Owner }}
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`))
+
+func testAccIncidentCatalogEntriesSetResourceConfig(id, owner string) string {
+	var buf bytes.Buffer
+	if err := catalogEntriesSetTemplate.Execute(&buf, struct {
+		ID    string
+		Owner string
+	}{
+		ID:    id,
+		Owner: owner,
+	}); err != nil {
+		panic(err)
+	}
+
+	return buf.String()
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -22,10 +23,13 @@ import (
 var (
 	_ resource.Resource                = &IncidentCatalogEntryResource{}
 	_ resource.ResourceWithImportState = &IncidentCatalogEntryResource{}
+	_ resource.ResourceWithModifyPlan  = &IncidentCatalogEntryResource{}
 )
 
 type IncidentCatalogEntryResource struct {
-	client *client.ClientWithResponses
+	client             *client.ClientWithResponses
+	apiUsage           *apiUsage
+	validateReferences bool
 }
 
 type IncidentCatalogEntryResourceModel struct {
@@ -35,6 +39,7 @@ type IncidentCatalogEntryResourceModel struct {
 	Aliases         types.List                   `tfsdk:"aliases"`
 	Rank            types.Int64                  `tfsdk:"rank"`
 	AttributeValues []CatalogEntryAttributeValue `tfsdk:"attribute_values"`
+	Timeouts        timeouts.Value               `tfsdk:"timeouts"`
 }
 
 func (m IncidentCatalogEntryResourceModel) buildAttributeValues() map[string]client.EngineParamBindingPayloadV2 {
@@ -145,6 +150,9 @@ If you're working with a large number of entries (>100) or want to be authoritat
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -164,15 +172,47 @@ func (r *IncidentCatalogEntryResource) Configure(ctx context.Context, req resour
 	}
 
 	r.client = client.Client
+	r.apiUsage = client.APIUsage
+	r.validateReferences = client.ValidateReferences
+}
+
+// ModifyPlan checks that the catalog type exists, for providers that set validate_references.
+func (r *IncidentCatalogEntryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_entry", "ModifyPlan")
+	defer done()
+
+	if req.Plan.Raw.IsNull() || !r.validateReferences {
+		return
+	}
+
+	var catalogTypeID types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("catalog_type_id"), &catalogTypeID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateReference(ctx, &resp.Diagnostics, path.Root("catalog_type_id"), "catalog type", catalogTypeID, catalogTypeExists(r.client))
 }
 
 func (r *IncidentCatalogEntryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_entry", "Create")
+	defer done()
+
 	var data *IncidentCatalogEntryResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	var rank *int32
 	if !data.Rank.IsNull() {
 		rank = lo.ToPtr(int32(data.Rank.ValueInt64()))
@@ -193,48 +233,76 @@ func (r *IncidentCatalogEntryResource) Create(ctx context.Context, req resource.
 		AttributeValues: data.buildAttributeValues(),
 	})
 	if err == nil && result.StatusCode() >= 400 {
-		err = fmt.Errorf(string(result.Body))
+		err = newAPIError(result.StatusCode(), result.Body)
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create catalog entry, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Unable to create catalog entry", err)
 		return
 	}
 
 	tflog.Trace(ctx, fmt.Sprintf("created a catalog entry resource with id=%s", result.JSON201.CatalogEntry.Id))
-	data = r.buildModel(result.JSON201.CatalogEntry)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON201.CatalogEntry)
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentCatalogEntryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_entry", "Read")
+	defer done()
+
 	var data *IncidentCatalogEntryResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	result, err := r.client.CatalogV2ShowEntryWithResponse(ctx, data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read catalog entry, got error: %s", err))
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if result.StatusCode() == 404 {
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	result, err := r.client.CatalogV2ShowEntryWithResponse(ctx, data.ID.ValueString())
+	if err == nil && result.StatusCode() == 404 {
 		resp.Diagnostics.AddWarning("Not Found", fmt.Sprintf("Unable to read catalog entry, got status code: %d", result.StatusCode()))
 		resp.State.RemoveResource(ctx)
 		return
 	}
+	if err == nil && result.StatusCode() >= 400 {
+		err = newAPIError(result.StatusCode(), result.Body)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read catalog entry, got error: %s", err))
+		return
+	}
 
-	data = r.buildModel(result.JSON200.CatalogEntry)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON200.CatalogEntry)
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentCatalogEntryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_entry", "Update")
+	defer done()
+
 	var data *IncidentCatalogEntryResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	var rank *int32
 	if !data.Rank.IsNull() {
 		rank = lo.ToPtr(int32(data.Rank.ValueInt64()))
@@ -254,24 +322,37 @@ func (r *IncidentCatalogEntryResource) Update(ctx context.Context, req resource.
 		AttributeValues: data.buildAttributeValues(),
 	})
 	if err == nil && result.StatusCode() >= 400 {
-		err = fmt.Errorf(string(result.Body))
+		err = newAPIError(result.StatusCode(), result.Body)
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update catalog entry, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Unable to update catalog entry", err)
 		return
 	}
 
-	data = r.buildModel(result.JSON200.CatalogEntry)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON200.CatalogEntry)
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentCatalogEntryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_entry", "Delete")
+	defer done()
+
 	var data *IncidentCatalogEntryResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	_, err := r.client.CatalogV2DestroyEntry(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete catalog entry, got error: %s", err))
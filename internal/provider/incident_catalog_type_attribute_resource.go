@@ -3,8 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -18,20 +21,25 @@ import (
 )
 
 var (
-	_ resource.Resource = &IncidentCatalogTypeAttributeResource{}
+	_ resource.Resource                = &IncidentCatalogTypeAttributeResource{}
+	_ resource.ResourceWithImportState = &IncidentCatalogTypeAttributeResource{}
+	_ resource.ResourceWithModifyPlan  = &IncidentCatalogTypeAttributeResource{}
 )
 
 type IncidentCatalogTypeAttributeResource struct {
-	client *client.ClientWithResponses
+	client             *client.ClientWithResponses
+	apiUsage           *apiUsage
+	validateReferences bool
 }
 
 type IncidentCatalogTypeAttributesResourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	CatalogTypeID     types.String `tfsdk:"catalog_type_id"`
-	Name              types.String `tfsdk:"name"`
-	Type              types.String `tfsdk:"type"`
-	Array             types.Bool   `tfsdk:"array"`
-	BacklinkAttribute types.String `tfsdk:"backlink_attribute"`
+	ID                types.String   `tfsdk:"id"`
+	CatalogTypeID     types.String   `tfsdk:"catalog_type_id"`
+	Name              types.String   `tfsdk:"name"`
+	Type              types.String   `tfsdk:"type"`
+	Array             types.Bool     `tfsdk:"array"`
+	BacklinkAttribute types.String   `tfsdk:"backlink_attribute"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (m IncidentCatalogTypeAttributesResourceModel) buildAttribute() client.CatalogTypeAttributePayloadV2 {
@@ -68,6 +76,28 @@ func (m IncidentCatalogTypeAttributesResourceModel) buildAttribute() client.Cata
 	}
 }
 
+// existingAttributePayload builds the payload that keeps an attribute we aren't managing
+// as it is, when we update the schema around it. That includes its mode, otherwise
+// updating the schema turns backlinks, and attributes managed by other integrations, into
+// manual attributes.
+func existingAttributePayload(attribute client.CatalogTypeAttributeV2) client.CatalogTypeAttributePayloadV2 {
+	var mode *client.CatalogTypeAttributePayloadV2Mode
+	if attribute.Mode != client.CatalogTypeAttributeV2ModeEmpty {
+		mode = lo.ToPtr(client.CatalogTypeAttributePayloadV2Mode(attribute.Mode))
+	} else if attribute.BacklinkAttribute != nil {
+		mode = lo.ToPtr(client.CatalogTypeAttributePayloadV2ModeBacklink)
+	}
+
+	return client.CatalogTypeAttributePayloadV2{
+		Id:                lo.ToPtr(attribute.Id),
+		Name:              attribute.Name,
+		Type:              attribute.Type,
+		Array:             attribute.Array,
+		BacklinkAttribute: attribute.BacklinkAttribute,
+		Mode:              mode,
+	}
+}
+
 func NewIncidentCatalogTypeAttributesResource() resource.Resource {
 	return &IncidentCatalogTypeAttributeResource{}
 }
@@ -111,6 +141,9 @@ func (r *IncidentCatalogTypeAttributeResource) Schema(ctx context.Context, req r
 				Optional:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -130,26 +163,52 @@ func (r *IncidentCatalogTypeAttributeResource) Configure(ctx context.Context, re
 	}
 
 	r.client = client.Client
+	r.apiUsage = client.APIUsage
+	r.validateReferences = client.ValidateReferences
+}
+
+// ModifyPlan checks that the catalog type exists, for providers that set validate_references.
+func (r *IncidentCatalogTypeAttributeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_type_attribute", "ModifyPlan")
+	defer done()
+
+	if req.Plan.Raw.IsNull() || !r.validateReferences {
+		return
+	}
+
+	var catalogTypeID types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("catalog_type_id"), &catalogTypeID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateReference(ctx, &resp.Diagnostics, path.Root("catalog_type_id"), "catalog type", catalogTypeID, catalogTypeExists(r.client))
 }
 
 func (r *IncidentCatalogTypeAttributeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_type_attribute", "Create")
+	defer done()
+
 	var data *IncidentCatalogTypeAttributesResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	var result *client.CatalogV2UpdateTypeSchemaResponse
 	err := r.lockFor(ctx, data.CatalogTypeID.ValueString(), func(ctx context.Context, catalogType client.CatalogTypeV2) error {
 		attributes := []client.CatalogTypeAttributePayloadV2{}
 		for _, attribute := range catalogType.Schema.Attributes {
-			attributes = append(attributes, client.CatalogTypeAttributePayloadV2{
-				Id:                lo.ToPtr(attribute.Id),
-				Name:              attribute.Name,
-				Type:              attribute.Type,
-				Array:             attribute.Array,
-				BacklinkAttribute: attribute.BacklinkAttribute,
-			})
+			attributes = append(attributes, existingAttributePayload(attribute))
 		}
 
 		// Add our new attribute.
@@ -161,7 +220,7 @@ func (r *IncidentCatalogTypeAttributeResource) Create(ctx context.Context, req r
 			Attributes: attributes,
 		})
 		if err == nil && result.StatusCode() >= 400 {
-			err = fmt.Errorf(string(result.Body))
+			err = newAPIError(result.StatusCode(), result.Body)
 		}
 		if err != nil {
 			return errors.Wrap(err, "Unable to update catalog type schema, got error")
@@ -186,37 +245,76 @@ func (r *IncidentCatalogTypeAttributeResource) Create(ctx context.Context, req r
 	}
 
 	tflog.Trace(ctx, fmt.Sprintf("Updated catalog type schema for id=%s", result.JSON200.CatalogType.Id))
-	data = r.buildModel(result.JSON200.CatalogType, attributeID)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON200.CatalogType, attributeID)
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentCatalogTypeAttributeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_type_attribute", "Read")
+	defer done()
+
 	var data *IncidentCatalogTypeAttributesResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	result, err := r.client.CatalogV2ShowTypeWithResponse(ctx, data.CatalogTypeID.ValueString())
+	if err == nil && result.StatusCode() == 404 {
+		resp.Diagnostics.AddWarning("Not Found", fmt.Sprintf("Unable to find catalog type with ID %s, removing attribute from state.", data.CatalogTypeID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err == nil && result.StatusCode() >= 400 {
-		err = fmt.Errorf(string(result.Body))
+		err = newAPIError(result.StatusCode(), result.Body)
 	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read catalog type, got error: %s", err))
 		return
 	}
 
-	data = r.buildModel(result.JSON200.CatalogType, data.ID.ValueString())
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if !lo.ContainsBy(result.JSON200.CatalogType.Schema.Attributes, func(attribute client.CatalogTypeAttributeV2) bool {
+		return attribute.Id == data.ID.ValueString()
+	}) {
+		resp.Diagnostics.AddWarning("Not Found", fmt.Sprintf("Unable to find catalog type attribute with ID %s, removing from state.", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	model := r.buildModel(result.JSON200.CatalogType, data.ID.ValueString())
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentCatalogTypeAttributeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_type_attribute", "Update")
+	defer done()
+
 	var data *IncidentCatalogTypeAttributesResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	var (
 		alreadyExists bool
 	)
@@ -232,19 +330,7 @@ func (r *IncidentCatalogTypeAttributeResource) Update(ctx context.Context, req r
 				alreadyExists = true
 				attributes = append(attributes, data.buildAttribute())
 			} else {
-				var mode *client.CatalogTypeAttributePayloadV2Mode
-				if attribute.BacklinkAttribute != nil {
-					mode = lo.ToPtr(client.CatalogTypeAttributePayloadV2ModeBacklink)
-				}
-
-				attributes = append(attributes, client.CatalogTypeAttributePayloadV2{
-					Id:                lo.ToPtr(attribute.Id),
-					Name:              attribute.Name,
-					Type:              attribute.Type,
-					Array:             attribute.Array,
-					BacklinkAttribute: attribute.BacklinkAttribute,
-					Mode:              mode,
-				})
+				attributes = append(attributes, existingAttributePayload(attribute))
 			}
 		}
 		if !alreadyExists {
@@ -259,7 +345,7 @@ func (r *IncidentCatalogTypeAttributeResource) Update(ctx context.Context, req r
 			Attributes: attributes,
 		})
 		if err == nil && result.StatusCode() >= 400 {
-			err = fmt.Errorf(string(result.Body))
+			err = newAPIError(result.StatusCode(), result.Body)
 		}
 		if err != nil {
 			return errors.Wrap(err, "Unable to update catalog type schema, got error")
@@ -288,17 +374,30 @@ func (r *IncidentCatalogTypeAttributeResource) Update(ctx context.Context, req r
 	}
 
 	tflog.Trace(ctx, fmt.Sprintf("Updated catalog type schema for catalog type with id=%s", result.JSON200.CatalogType.Id))
-	data = r.buildModel(result.JSON200.CatalogType, attributeID)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON200.CatalogType, attributeID)
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentCatalogTypeAttributeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_type_attribute", "Delete")
+	defer done()
+
 	var data *IncidentCatalogTypeAttributesResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	err := r.lockFor(ctx, data.CatalogTypeID.ValueString(), func(ctx context.Context, catalogType client.CatalogTypeV2) error {
 		attributes := []client.CatalogTypeAttributePayloadV2{}
 		for _, attribute := range catalogType.Schema.Attributes {
@@ -306,19 +405,7 @@ func (r *IncidentCatalogTypeAttributeResource) Delete(ctx context.Context, req r
 				continue
 			}
 
-			var mode *client.CatalogTypeAttributePayloadV2Mode
-			if attribute.BacklinkAttribute != nil {
-				mode = lo.ToPtr(client.CatalogTypeAttributePayloadV2ModeBacklink)
-			}
-
-			attributes = append(attributes, client.CatalogTypeAttributePayloadV2{
-				Id:                lo.ToPtr(attribute.Id),
-				Name:              attribute.Name,
-				Type:              attribute.Type,
-				Array:             attribute.Array,
-				BacklinkAttribute: attribute.BacklinkAttribute,
-				Mode:              mode,
-			})
+			attributes = append(attributes, existingAttributePayload(attribute))
 		}
 
 		result, err := r.client.CatalogV2UpdateTypeSchemaWithResponse(ctx, data.CatalogTypeID.ValueString(), client.UpdateTypeSchemaRequestBody{
@@ -326,7 +413,7 @@ func (r *IncidentCatalogTypeAttributeResource) Delete(ctx context.Context, req r
 			Attributes: attributes,
 		})
 		if err == nil && result.StatusCode() >= 400 {
-			err = fmt.Errorf(string(result.Body))
+			err = newAPIError(result.StatusCode(), result.Body)
 		}
 		if err != nil {
 			return errors.Wrap(err, "Unable to update catalog type schema, got error")
@@ -340,6 +427,25 @@ func (r *IncidentCatalogTypeAttributeResource) Delete(ctx context.Context, req r
 	}
 }
 
+// ImportState expects an ID of the form catalog_type_id:attribute_id, as attributes can
+// only be read through the catalog type they belong to.
+func (r *IncidentCatalogTypeAttributeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_type_attribute", "ImportState")
+	defer done()
+
+	catalogTypeID, attributeID, ok := strings.Cut(req.ID, ":")
+	if !ok || catalogTypeID == "" || attributeID == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: catalog_type_id:attribute_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("catalog_type_id"), catalogTypeID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), attributeID)...)
+}
+
 func (r *IncidentCatalogTypeAttributeResource) buildModel(catalogType client.CatalogTypeV2, attributeID string) *IncidentCatalogTypeAttributesResourceModel {
 	result := &IncidentCatalogTypeAttributesResourceModel{
 		ID:            types.StringValue(attributeID),
@@ -380,7 +486,7 @@ func (r *IncidentCatalogTypeAttributeResource) lockFor(ctx context.Context, cata
 
 	typeResult, err := r.client.CatalogV2ShowTypeWithResponse(ctx, catalogTypeID)
 	if err == nil && typeResult.StatusCode() >= 400 {
-		err = fmt.Errorf(string(typeResult.Body))
+		err = newAPIError(typeResult.StatusCode(), typeResult.Body)
 	}
 	if err != nil {
 		return errors.Wrap(err, "Unable to get catalog type, got error")
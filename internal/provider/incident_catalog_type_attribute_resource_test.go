@@ -2,12 +2,14 @@ package provider
 
 import (
 	"bytes"
+	"fmt"
 	"testing"
 	"text/template"
 
 	"github.com/Masterminds/sprig"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/incident-io/terraform-provider-incident/internal/client"
 )
 
@@ -47,6 +49,16 @@ func TestAccIncidentCatalogTypeAttributeResource(t *testing.T) {
 						"incident_catalog_type_attribute.example", "array", "true"),
 				),
 			},
+			// Import
+			{
+				ResourceName:      "incident_catalog_type_attribute.example",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					attribute := s.RootModule().Resources["incident_catalog_type_attribute.example"].Primary
+					return fmt.Sprintf("%s:%s", attribute.Attributes["catalog_type_id"], attribute.ID), nil
+				},
+			},
 		},
 	})
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -21,16 +22,19 @@ var (
 )
 
 type IncidentCatalogTypeResource struct {
-	client           *client.ClientWithResponses
-	terraformVersion string
+	client                    *client.ClientWithResponses
+	apiUsage                  *apiUsage
+	terraformVersion          string
+	skipManagementAnnotations bool
 }
 
 type IncidentCatalogTypeResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	Name          types.String `tfsdk:"name"`
-	TypeName      types.String `tfsdk:"type_name"`
-	Description   types.String `tfsdk:"description"`
-	SourceRepoURL types.String `tfsdk:"source_repo_url"`
+	ID            types.String   `tfsdk:"id"`
+	Name          types.String   `tfsdk:"name"`
+	TypeName      types.String   `tfsdk:"type_name"`
+	Description   types.String   `tfsdk:"description"`
+	SourceRepoURL types.String   `tfsdk:"source_repo_url"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewIncidentCatalogTypeResource() resource.Resource {
@@ -73,6 +77,9 @@ func (r *IncidentCatalogTypeResource) Schema(ctx context.Context, req resource.S
 				Optional:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -92,22 +99,34 @@ func (r *IncidentCatalogTypeResource) Configure(ctx context.Context, req resourc
 	}
 
 	r.client = client.Client
+	r.apiUsage = client.APIUsage
 	r.terraformVersion = client.TerraformVersion
+	r.skipManagementAnnotations = client.SkipManagementAnnotations
 }
 
 func (r *IncidentCatalogTypeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_type", "Create")
+	defer done()
+
 	var data *IncidentCatalogTypeResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	requestBody := client.CreateTypeRequestBody{
 		Name:        data.Name.ValueString(),
 		Description: data.Description.ValueString(),
-		Annotations: &map[string]string{
-			"incident.io/terraform/version": r.terraformVersion,
-		},
+		Annotations: managementAnnotations(r.terraformVersion, r.skipManagementAnnotations),
 	}
 	if typeName := data.TypeName.ValueString(); typeName != "" {
 		requestBody.TypeName = &typeName
@@ -118,52 +137,81 @@ func (r *IncidentCatalogTypeResource) Create(ctx context.Context, req resource.C
 
 	result, err := r.client.CatalogV2CreateTypeWithResponse(ctx, requestBody)
 	if err == nil && result.StatusCode() >= 400 {
-		err = fmt.Errorf(string(result.Body))
+		err = newAPIError(result.StatusCode(), result.Body)
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create catalog type, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Unable to create catalog type", err)
 		return
 	}
 
 	tflog.Trace(ctx, fmt.Sprintf("created a catalog type resource with id=%s", result.JSON201.CatalogType.Id))
-	data = r.buildModel(result.JSON201.CatalogType)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON201.CatalogType)
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentCatalogTypeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_type", "Read")
+	defer done()
+
 	var data *IncidentCatalogTypeResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	result, err := r.client.CatalogV2ShowTypeWithResponse(ctx, data.ID.ValueString())
+	if err == nil && result.StatusCode() == 404 {
+		resp.Diagnostics.AddWarning("Not Found", fmt.Sprintf("Unable to read catalog type, got status code: %d", result.StatusCode()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err == nil && result.StatusCode() >= 400 {
-		err = fmt.Errorf(string(result.Body))
+		err = newAPIError(result.StatusCode(), result.Body)
 	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read catalog type, got error: %s", err))
 		return
 	}
 
-	data = r.buildModel(result.JSON200.CatalogType)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON200.CatalogType)
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentCatalogTypeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_type", "Update")
+	defer done()
+
 	var data *IncidentCatalogTypeResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	requestBody := client.CatalogV2UpdateTypeJSONRequestBody{
 		Name: data.Name.ValueString(),
 		// TypeName cannot be changed once set
 		Description: data.Description.ValueString(),
-		Annotations: &map[string]string{
-			"incident.io/terraform/version": r.terraformVersion,
-		},
+		Annotations: managementAnnotations(r.terraformVersion, r.skipManagementAnnotations),
 	}
 
 	if sourceRepoURL := data.SourceRepoURL.ValueString(); sourceRepoURL != "" {
@@ -172,24 +220,37 @@ func (r *IncidentCatalogTypeResource) Update(ctx context.Context, req resource.U
 
 	result, err := r.client.CatalogV2UpdateTypeWithResponse(ctx, data.ID.ValueString(), requestBody)
 	if err == nil && result.StatusCode() >= 400 {
-		err = fmt.Errorf(string(result.Body))
+		err = newAPIError(result.StatusCode(), result.Body)
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update catalog type, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Unable to update catalog type", err)
 		return
 	}
 
-	data = r.buildModel(result.JSON200.CatalogType)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON200.CatalogType)
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentCatalogTypeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_catalog_type", "Delete")
+	defer done()
+
 	var data *IncidentCatalogTypeResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	_, err := r.client.CatalogV2DestroyTypeWithResponse(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete catalog type, got error: %s", err))
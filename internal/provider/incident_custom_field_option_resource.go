@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -19,17 +20,21 @@ import (
 var (
 	_ resource.Resource                = &IncidentCustomFieldOptionResource{}
 	_ resource.ResourceWithImportState = &IncidentCustomFieldOptionResource{}
+	_ resource.ResourceWithModifyPlan  = &IncidentCustomFieldOptionResource{}
 )
 
 type IncidentCustomFieldOptionResource struct {
-	client *client.ClientWithResponses
+	client             *client.ClientWithResponses
+	apiUsage           *apiUsage
+	validateReferences bool
 }
 
 type IncidentCustomFieldOptionResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	CustomFieldID types.String `tfsdk:"custom_field_id"`
-	SortKey       types.Int64  `tfsdk:"sort_key"`
-	Value         types.String `tfsdk:"value"`
+	ID            types.String   `tfsdk:"id"`
+	CustomFieldID types.String   `tfsdk:"custom_field_id"`
+	SortKey       types.Int64    `tfsdk:"sort_key"`
+	Value         types.String   `tfsdk:"value"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewIncidentCustomFieldOptionResource() resource.Resource {
@@ -68,6 +73,9 @@ func (r *IncidentCustomFieldOptionResource) Schema(ctx context.Context, req reso
 				Required:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -87,15 +95,47 @@ func (r *IncidentCustomFieldOptionResource) Configure(ctx context.Context, req r
 	}
 
 	r.client = client.Client
+	r.apiUsage = client.APIUsage
+	r.validateReferences = client.ValidateReferences
+}
+
+// ModifyPlan checks that the custom field exists, for providers that set validate_references.
+func (r *IncidentCustomFieldOptionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_custom_field_option", "ModifyPlan")
+	defer done()
+
+	if req.Plan.Raw.IsNull() || !r.validateReferences {
+		return
+	}
+
+	var customFieldID types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("custom_field_id"), &customFieldID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateReference(ctx, &resp.Diagnostics, path.Root("custom_field_id"), "custom field", customFieldID, customFieldExists(r.client))
 }
 
 func (r *IncidentCustomFieldOptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_custom_field_option", "Create")
+	defer done()
+
 	var data *IncidentCustomFieldOptionResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	var sortKey *int64
 	if !data.SortKey.IsNull() {
 		sortKey = lo.ToPtr(data.SortKey.ValueInt64())
@@ -106,71 +146,112 @@ func (r *IncidentCustomFieldOptionResource) Create(ctx context.Context, req reso
 		Value:         data.Value.ValueString(),
 	})
 	if err == nil && result.StatusCode() >= 400 {
-		err = fmt.Errorf(string(result.Body))
+		err = newAPIError(result.StatusCode(), result.Body)
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create custom field option, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Unable to create custom field option", err)
 		return
 	}
 
 	tflog.Trace(ctx, fmt.Sprintf("created a custom field option resource with id=%s", result.JSON201.CustomFieldOption.Id))
-	data = r.buildModel(result.JSON201.CustomFieldOption)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON201.CustomFieldOption)
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentCustomFieldOptionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_custom_field_option", "Read")
+	defer done()
+
 	var data *IncidentCustomFieldOptionResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	result, err := r.client.CustomFieldOptionsV1ShowWithResponse(ctx, data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read custom field option, got error: %s", err))
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if result.StatusCode() == 404 {
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	result, err := r.client.CustomFieldOptionsV1ShowWithResponse(ctx, data.ID.ValueString())
+	if err == nil && result.StatusCode() == 404 {
 		resp.Diagnostics.AddWarning("Not Found", fmt.Sprintf("Unable to read custom field option, got status code: %d", result.StatusCode()))
 		resp.State.RemoveResource(ctx)
 		return
 	}
+	if err == nil && result.StatusCode() >= 400 {
+		err = newAPIError(result.StatusCode(), result.Body)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read custom field option, got error: %s", err))
+		return
+	}
 
-	data = r.buildModel(result.JSON200.CustomFieldOption)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON200.CustomFieldOption)
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentCustomFieldOptionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_custom_field_option", "Update")
+	defer done()
+
 	var data *IncidentCustomFieldOptionResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	result, err := r.client.CustomFieldOptionsV1UpdateWithResponse(ctx, data.ID.ValueString(), client.CustomFieldOptionsV1UpdateJSONRequestBody{
 		SortKey: data.SortKey.ValueInt64(),
 		Value:   data.Value.ValueString(),
 	})
 	if err == nil && result.StatusCode() >= 400 {
-		err = fmt.Errorf(string(result.Body))
+		err = newAPIError(result.StatusCode(), result.Body)
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update custom field, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Unable to update custom field", err)
 		return
 	}
 
-	data = r.buildModel(result.JSON200.CustomFieldOption)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON200.CustomFieldOption)
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentCustomFieldOptionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_custom_field_option", "Delete")
+	defer done()
+
 	var data *IncidentCustomFieldOptionResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	_, err := r.client.CustomFieldOptionsV1DeleteWithResponse(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete custom field option, got error: %s", err))
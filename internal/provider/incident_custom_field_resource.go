@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/incident-io/terraform-provider-incident/internal/apischema"
@@ -21,14 +23,16 @@ var (
 )
 
 type IncidentCustomFieldResource struct {
-	client *client.ClientWithResponses
+	client   *client.ClientWithResponses
+	apiUsage *apiUsage
 }
 
 type IncidentCustomFieldResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	FieldType   types.String `tfsdk:"field_type"`
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	FieldType   types.String   `tfsdk:"field_type"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewIncidentCustomFieldResource() resource.Resource {
@@ -61,11 +65,17 @@ func (r *IncidentCustomFieldResource) Schema(ctx context.Context, req resource.S
 			"field_type": schema.StringAttribute{
 				MarkdownDescription: apischema.Docstring("CustomFieldsV2CreateRequestBody", "field_type"),
 				Required:            true,
+				Validators: []validator.String{
+					apischema.OneOf("CustomFieldsV2CreateRequestBody", "field_type"),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -85,86 +95,140 @@ func (r *IncidentCustomFieldResource) Configure(ctx context.Context, req resourc
 	}
 
 	r.client = client.Client
+	r.apiUsage = client.APIUsage
 }
 
 func (r *IncidentCustomFieldResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_custom_field", "Create")
+	defer done()
+
 	var data *IncidentCustomFieldResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	result, err := r.client.CustomFieldsV2CreateWithResponse(ctx, client.CustomFieldsV2CreateJSONRequestBody{
 		Name:        data.Name.ValueString(),
 		Description: data.Description.ValueString(),
 		FieldType:   client.CreateRequestBody3FieldType(data.FieldType.ValueString()),
 	})
 	if err == nil && result.StatusCode() >= 400 {
-		err = fmt.Errorf(string(result.Body))
+		err = newAPIError(result.StatusCode(), result.Body)
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create custom field, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Unable to create custom field", err)
 		return
 	}
 
 	tflog.Trace(ctx, fmt.Sprintf("created a custom field resource with id=%s", result.JSON201.CustomField.Id))
-	data = r.buildModel(result.JSON201.CustomField)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON201.CustomField)
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentCustomFieldResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_custom_field", "Read")
+	defer done()
+
 	var data *IncidentCustomFieldResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	result, err := r.client.CustomFieldsV2ShowWithResponse(ctx, data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read custom field, got error: %s", err))
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if result.StatusCode() == 404 {
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	result, err := r.client.CustomFieldsV2ShowWithResponse(ctx, data.ID.ValueString())
+	if err == nil && result.StatusCode() == 404 {
 		resp.Diagnostics.AddWarning("Not Found", fmt.Sprintf("Unable to read custom field, got status code: %d", result.StatusCode()))
 		resp.State.RemoveResource(ctx)
 		return
 	}
+	if err == nil && result.StatusCode() >= 400 {
+		err = newAPIError(result.StatusCode(), result.Body)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read custom field, got error: %s", err))
+		return
+	}
 
-	data = r.buildModel(result.JSON200.CustomField)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON200.CustomField)
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentCustomFieldResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_custom_field", "Update")
+	defer done()
+
 	var data *IncidentCustomFieldResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	result, err := r.client.CustomFieldsV2UpdateWithResponse(ctx, data.ID.ValueString(), client.CustomFieldsV2UpdateJSONRequestBody{
 		Name:        data.Name.ValueString(),
 		Description: data.Description.ValueString(),
 	})
 	if err == nil && result.StatusCode() >= 400 {
-		err = fmt.Errorf(string(result.Body))
+		err = newAPIError(result.StatusCode(), result.Body)
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update custom field, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Unable to update custom field", err)
 		return
 	}
 
-	data = r.buildModel(result.JSON200.CustomField)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON200.CustomField)
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentCustomFieldResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_custom_field", "Delete")
+	defer done()
+
 	var data *IncidentCustomFieldResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	_, err := r.client.CustomFieldsV2DeleteWithResponse(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete custom field, got error: %s", err))
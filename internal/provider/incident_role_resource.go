@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -21,15 +22,17 @@ var (
 )
 
 type IncidentRoleResource struct {
-	client *client.ClientWithResponses
+	client   *client.ClientWithResponses
+	apiUsage *apiUsage
 }
 
 type IncidentRoleResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Description  types.String `tfsdk:"description"`
-	Instructions types.String `tfsdk:"instructions"`
-	Shortform    types.String `tfsdk:"shortform"`
+	ID           types.String   `tfsdk:"id"`
+	Name         types.String   `tfsdk:"name"`
+	Description  types.String   `tfsdk:"description"`
+	Instructions types.String   `tfsdk:"instructions"`
+	Shortform    types.String   `tfsdk:"shortform"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewIncidentRoleResource() resource.Resource {
@@ -68,6 +71,9 @@ func (r *IncidentRoleResource) Schema(ctx context.Context, req resource.SchemaRe
 				Required:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -87,15 +93,28 @@ func (r *IncidentRoleResource) Configure(ctx context.Context, req resource.Confi
 	}
 
 	r.client = client.Client
+	r.apiUsage = client.APIUsage
 }
 
 func (r *IncidentRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_incident_role", "Create")
+	defer done()
+
 	var data *IncidentRoleResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	result, err := r.client.IncidentRolesV2CreateWithResponse(ctx, client.IncidentRolesV2CreateJSONRequestBody{
 		Name:         data.Name.ValueString(),
 		Description:  data.Description.ValueString(),
@@ -103,42 +122,76 @@ func (r *IncidentRoleResource) Create(ctx context.Context, req resource.CreateRe
 		Shortform:    data.Shortform.ValueString(),
 	})
 	if err == nil && result.StatusCode() >= 400 {
-		err = fmt.Errorf(string(result.Body))
+		err = newAPIError(result.StatusCode(), result.Body)
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create incident role, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Unable to create incident role", err)
 		return
 	}
 
 	tflog.Trace(ctx, fmt.Sprintf("created an incident role resource with id=%s", result.JSON201.IncidentRole.Id))
-	data = r.buildModel(result.JSON201.IncidentRole)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON201.IncidentRole)
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_incident_role", "Read")
+	defer done()
+
 	var data *IncidentRoleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	result, err := r.client.IncidentRolesV2ShowWithResponse(ctx, data.ID.ValueString())
+	if err == nil && result.StatusCode() == 404 {
+		resp.Diagnostics.AddWarning("Not Found", fmt.Sprintf("Unable to read incident role, got status code: %d", result.StatusCode()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err == nil && result.StatusCode() >= 400 {
+		err = newAPIError(result.StatusCode(), result.Body)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read incident role, got error: %s", err))
 		return
 	}
 
-	data = r.buildModel(result.JSON200.IncidentRole)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON200.IncidentRole)
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_incident_role", "Update")
+	defer done()
+
 	var data *IncidentRoleResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	result, err := r.client.IncidentRolesV2UpdateWithResponse(ctx, data.ID.ValueString(), client.IncidentRolesV2UpdateJSONRequestBody{
 		Name:         data.Name.ValueString(),
 		Description:  data.Description.ValueString(),
@@ -146,27 +199,40 @@ func (r *IncidentRoleResource) Update(ctx context.Context, req resource.UpdateRe
 		Shortform:    data.Shortform.ValueString(),
 	})
 	if err == nil && result.StatusCode() >= 400 {
-		err = fmt.Errorf(string(result.Body))
+		err = newAPIError(result.StatusCode(), result.Body)
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update incident role, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Unable to update incident role", err)
 		return
 	}
 
-	data = r.buildModel(result.JSON200.IncidentRole)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON200.IncidentRole)
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_incident_role", "Delete")
+	defer done()
+
 	var data *IncidentRoleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	result, err := r.client.IncidentRolesV2DeleteWithResponse(ctx, data.ID.ValueString())
 	if err == nil && result.StatusCode() >= 400 {
-		err = fmt.Errorf(string(result.Body))
+		err = newAPIError(result.StatusCode(), result.Body)
 	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete incident role, got error: %s", err))
@@ -3,42 +3,68 @@ package provider
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/incident-io/terraform-provider-incident/internal/apischema"
 	"github.com/incident-io/terraform-provider-incident/internal/client"
+	"github.com/pkg/errors"
 	"github.com/samber/lo"
 )
 
 var (
-	_ resource.Resource                = &IncidentScheduleResource{}
-	_ resource.ResourceWithImportState = &IncidentScheduleResource{}
+	_ resource.Resource                   = &IncidentScheduleResource{}
+	_ resource.ResourceWithImportState    = &IncidentScheduleResource{}
+	_ resource.ResourceWithModifyPlan     = &IncidentScheduleResource{}
+	_ resource.ResourceWithValidateConfig = &IncidentScheduleResource{}
 )
 
 type IncidentScheduleResource struct {
-	client           *client.ClientWithResponses
-	terraformVersion string
+	client                       *client.ClientWithResponses
+	apiUsage                     *apiUsage
+	terraformVersion             string
+	protectDestructiveOperations bool
+	adoptUnmanaged               bool
+	skipManagementAnnotations    bool
+	validateReferences           bool
 }
 
 type IncidentScheduleResourceModel struct {
-	ID        types.String `tfsdk:"id"`
-	Name      types.String `tfsdk:"name"`
-	Timezone  types.String `tfsdk:"timezone"`
-	Rotations []Rotation   `tfsdk:"rotations"`
+	ID                         types.String   `tfsdk:"id"`
+	Name                       types.String   `tfsdk:"name"`
+	Timezone                   types.String   `tfsdk:"timezone"`
+	Rotations                  []Rotation     `tfsdk:"rotations"`
+	VersionRetentionDays       types.Int64    `tfsdk:"version_retention_days"`
+	ValidateUsers              types.Bool     `tfsdk:"validate_users"`
+	Annotations                types.Map      `tfsdk:"annotations"`
+	CloneFrom                  types.String   `tfsdk:"clone_from"`
+	ShiftWarningDays           types.Int64    `tfsdk:"shift_warning_days"`
+	AllowDestructiveOperations types.Bool     `tfsdk:"allow_destructive_operations"`
+	Timeouts                   timeouts.Value `tfsdk:"timeouts"`
 }
 
 type Rotation struct {
-	ID       types.String      `tfsdk:"id"`
-	Name     types.String      `tfsdk:"name"`
-	Versions []RotationVersion `tfsdk:"versions"`
+	ID             types.String      `tfsdk:"id"`
+	Name           types.String      `tfsdk:"name"`
+	Versions       []RotationVersion `tfsdk:"versions"`
+	OnCallUserIDs  types.List        `tfsdk:"on_call_user_ids"`
+	NextHandoverAt types.String      `tfsdk:"next_handover_at"`
 }
 
 type RotationVersion struct {
@@ -48,6 +74,16 @@ type RotationVersion struct {
 	Users            []types.String    `tfsdk:"users"`
 	WorkingIntervals []WorkingInterval `tfsdk:"working_intervals"`
 	Layers           []Layer           `tfsdk:"layers"`
+	LayerCount       types.Int64       `tfsdk:"layer_count"`
+	Handover         *HandoverSchedule `tfsdk:"handover"`
+}
+
+// HandoverSchedule is a friendlier way to describe when handovers happen, which we
+// convert into handover_start_at and handovers when planning.
+type HandoverSchedule struct {
+	Every types.String `tfsdk:"every"`
+	On    types.String `tfsdk:"on"`
+	At    types.String `tfsdk:"at"`
 }
 
 type WorkingInterval struct {
@@ -90,7 +126,11 @@ func (r *IncidentScheduleResource) Schema(ctx context.Context, req resource.Sche
 				MarkdownDescription: apischema.Docstring("ScheduleV2ResponseBody", "name"),
 			},
 			"timezone": schema.StringAttribute{
-				Required: true,
+				Required:            true,
+				MarkdownDescription: "Timezone of the schedule, from the IANA timezone database such as `Europe/London`. Aliases such as `US/Eastern` are sent to the API as their canonical name.",
+				Validators: []validator.String{
+					timezoneValidator{},
+				},
 			},
 			"rotations": schema.ListNestedAttribute{
 				NestedObject: schema.NestedAttributeObject{
@@ -103,6 +143,15 @@ func (r *IncidentScheduleResource) Schema(ctx context.Context, req resource.Sche
 							Required:            true,
 							MarkdownDescription: apischema.Docstring("ScheduleRotationV2ResponseBody", "name"),
 						},
+						"on_call_user_ids": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "IDs of the users currently on-call for this rotation, as of the last refresh.",
+						},
+						"next_handover_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the current shift for this rotation ends, as of the last refresh. This is null if nobody is currently on-call.",
+						},
 						"versions": schema.ListNestedAttribute{
 							Required: true,
 							NestedObject: schema.NestedAttributeObject{
@@ -110,15 +159,37 @@ func (r *IncidentScheduleResource) Schema(ctx context.Context, req resource.Sche
 									"users": schema.ListAttribute{
 										Required:            true,
 										ElementType:         types.StringType,
-										MarkdownDescription: apischema.Docstring("UserReferencePayloadV1RequestBody", "id"),
+										MarkdownDescription: "The incident.io ID or email address of each user in the rotation. Email addresses are resolved to users when applying.",
 									},
 									"effective_from": schema.StringAttribute{
 										Optional:            true,
-										MarkdownDescription: apischema.Docstring("ScheduleRotationV2ResponseBody", "effective_from"),
+										MarkdownDescription: apischema.Docstring("ScheduleRotationV2ResponseBody", "effective_from") + ". Required for every version other than the first, and must be after the effective_from of the version before.",
 									},
 									"handover_start_at": schema.StringAttribute{
-										Required:            true,
-										MarkdownDescription: apischema.Docstring("ScheduleRotationV2ResponseBody", "handover_start_at"),
+										Optional:            true,
+										Computed:            true,
+										MarkdownDescription: apischema.Docstring("ScheduleRotationV2ResponseBody", "handover_start_at") + ". Either this or handover must be set.",
+									},
+									"handover": schema.SingleNestedAttribute{
+										Optional:            true,
+										MarkdownDescription: "A simpler way to configure handovers, which is converted into handover_start_at and handovers when planning. Can't be used alongside either of them.",
+										Attributes: map[string]schema.Attribute{
+											"every": schema.StringAttribute{
+												Required:            true,
+												MarkdownDescription: "How often the rotation hands over, as a number of hours, days or weeks such as `12h`, `1d` or `2w`.",
+											},
+											"on": schema.StringAttribute{
+												Optional:            true,
+												MarkdownDescription: "For weekly handovers, the day of the week to hand over on. Defaults to `monday`.",
+												Validators: []validator.String{
+													apischema.OneOf("ScheduleRotationWorkingIntervalV2ResponseBody", "weekday"),
+												},
+											},
+											"at": schema.StringAttribute{
+												Optional:            true,
+												MarkdownDescription: "The time of day to hand over at in the schedule's timezone, in 24hr format. Defaults to `09:00`.",
+											},
+										},
 									},
 									"working_intervals": schema.ListNestedAttribute{
 										Optional:            true,
@@ -126,20 +197,34 @@ func (r *IncidentScheduleResource) Schema(ctx context.Context, req resource.Sche
 										NestedObject: schema.NestedAttributeObject{
 											Attributes: map[string]schema.Attribute{
 												"start": schema.StringAttribute{
-													Required: true,
+													Required:            true,
+													MarkdownDescription: "Start time of the interval, in 24hr format such as `09:00`",
 												},
 												"end": schema.StringAttribute{
-													Required: true,
+													Required:            true,
+													MarkdownDescription: "End time of the interval, in 24hr format such as `17:00`. If this is before the start, the interval runs overnight into the following day, and is sent to the API as two intervals split at midnight, the first ending at 23:59.",
 												},
 												"day": schema.StringAttribute{
-													Required: true,
+													Required:            true,
+													MarkdownDescription: apischema.Docstring("ScheduleRotationWorkingIntervalV2ResponseBody", "weekday"),
+													Validators: []validator.String{
+														apischema.OneOf("ScheduleRotationWorkingIntervalV2ResponseBody", "weekday"),
+													},
 												},
 											},
 										},
 									},
+									"layer_count": schema.Int64Attribute{
+										Optional:            true,
+										MarkdownDescription: "Number of layers to generate for this version, as an alternative to listing them in layers. Generated layers have IDs of the form `<rotation id>-layer-<n>` and are named `Layer <n>`, so they stay the same across versions.",
+										Validators: []validator.Int64{
+											int64validator.AtLeast(1),
+										},
+									},
 									"layers": schema.ListNestedAttribute{
-										Required:            true,
-										MarkdownDescription: apischema.Docstring("ScheduleRotationV2ResponseBody", "layers"),
+										Optional:            true,
+										Computed:            true,
+										MarkdownDescription: apischema.Docstring("ScheduleRotationV2ResponseBody", "layers") + ". Either this or layer_count must be set.",
 										NestedObject: schema.NestedAttributeObject{
 											Attributes: map[string]schema.Attribute{
 												"id": schema.StringAttribute{
@@ -153,14 +238,21 @@ func (r *IncidentScheduleResource) Schema(ctx context.Context, req resource.Sche
 									},
 									"handovers": schema.ListNestedAttribute{
 										Optional:            true,
+										Computed:            true,
 										MarkdownDescription: apischema.Docstring("ScheduleRotationV2ResponseBody", "handovers"),
 										NestedObject: schema.NestedAttributeObject{
 											Attributes: map[string]schema.Attribute{
 												"interval": schema.Int64Attribute{
 													Required: true,
+													Validators: []validator.Int64{
+														int64validator.AtLeast(1),
+													},
 												},
 												"interval_type": schema.StringAttribute{
 													Required: true,
+													Validators: []validator.String{
+														apischema.OneOf("ScheduleRotationHandoverV2ResponseBody", "interval_type"),
+													},
 												},
 											},
 										},
@@ -170,9 +262,238 @@ func (r *IncidentScheduleResource) Schema(ctx context.Context, req resource.Sche
 						},
 					},
 				},
-				Required: true,
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The rotations of the schedule. Either this or clone_from must be set.",
+			},
+			"clone_from": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The ID or name of an existing schedule to copy rotations from when this schedule is created, instead of configuring rotations. Each rotation is copied as it is currently configured, and is given a new ID. Changing this after the schedule has been created has no effect.",
+			},
+			"version_retention_days": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "If set, rotation versions that were replaced by a later version more than this many days ago are no longer sent to the API, removing them from the schedule. They can then be deleted from the configuration at your leisure without producing a diff.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"annotations": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Annotations to track metadata about the schedule, such as the team or repository that owns it. These are merged with the annotations set by the provider, and keys may not use the reserved `incident.io/` prefix.",
+			},
+			"shift_warning_days": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("When a plan removes a user from a rotation, warn if they have shifts on that rotation within this many days. Defaults to %d, and 0 disables the warning.", scheduleDefaultShiftWarningDays),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"validate_users": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "If true, check that every user in the schedule exists when planning, reporting an error for each one that doesn't. This catches users that have left the organisation before an apply fails. Always on when the provider sets `validate_references`.",
+			},
+			"allow_destructive_operations": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "If true, this schedule can be destroyed even when the provider sets `protect_destructive_operations`. This must be applied before the destroy is planned.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *IncidentScheduleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// We'll be asked to validate again once everything is known.
+	if !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var data *IncidentScheduleResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Rotations != nil && !data.CloneFrom.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("clone_from"), "Invalid Attribute Combination", "Only one of rotations or clone_from can be set.")
+	}
+	if data.Rotations == nil && data.CloneFrom.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("rotations"), "Missing Attribute Configuration", "One of rotations or clone_from must be set.")
+	}
+
+	for key := range data.Annotations.Elements() {
+		if strings.HasPrefix(key, scheduleReservedAnnotationPrefix) {
+			resp.Diagnostics.AddAttributeError(path.Root("annotations").AtMapKey(key), "Reserved Annotation", fmt.Sprintf("Annotation keys beginning with %q are reserved for use by incident.io.", scheduleReservedAnnotationPrefix))
+		}
+	}
+
+	for rotationIdx, rotation := range data.Rotations {
+		var previousEffectiveFrom *time.Time
+		for versionIdx, version := range rotation.Versions {
+			versionPath := path.Root("rotations").AtListIndex(rotationIdx).AtName("versions").AtListIndex(versionIdx)
+
+			// Versions are applied in order, so each must take effect after the one before it.
+			// Only the first can omit effective_from, meaning it applies immediately.
+			if version.EffectiveFrom.IsNull() {
+				if versionIdx > 0 {
+					resp.Diagnostics.AddAttributeError(versionPath.AtName("effective_from"), "Missing Attribute Configuration", "Every version other than the first must set effective_from.")
+				}
+			} else if effectiveFrom, err := time.Parse(time.RFC3339, version.EffectiveFrom.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(versionPath.AtName("effective_from"), "Invalid Timestamp", fmt.Sprintf("%q must be an RFC3339 timestamp, such as 2024-01-01T09:00:00Z.", version.EffectiveFrom.ValueString()))
+			} else {
+				if previousEffectiveFrom != nil && !effectiveFrom.After(*previousEffectiveFrom) {
+					resp.Diagnostics.AddAttributeError(versionPath.AtName("effective_from"), "Invalid Version Order", fmt.Sprintf("Versions must be listed in the order they take effect, but this version's effective_from is not after %s.", previousEffectiveFrom.Format(time.RFC3339)))
+				}
+				previousEffectiveFrom = &effectiveFrom
+			}
+
+			if version.Layers != nil && !version.LayerCount.IsNull() {
+				resp.Diagnostics.AddAttributeError(versionPath.AtName("layer_count"), "Invalid Attribute Combination", "Only one of layers or layer_count can be set.")
+			}
+			if version.Layers == nil && version.LayerCount.IsNull() {
+				resp.Diagnostics.AddAttributeError(versionPath.AtName("layers"), "Missing Attribute Configuration", "One of layers or layer_count must be set.")
+			}
+
+			if version.Handover != nil {
+				if !version.HandoverStartAt.IsNull() || version.Handovers != nil {
+					resp.Diagnostics.AddAttributeError(versionPath.AtName("handover"), "Invalid Attribute Combination", "handover can't be used alongside handover_start_at or handovers.")
+				}
+				if _, err := buildHandoverSchedule(*version.Handover, "UTC"); err != nil {
+					resp.Diagnostics.AddAttributeError(versionPath.AtName("handover"), "Invalid Handover", err.Error())
+				}
+			} else if version.HandoverStartAt.IsNull() {
+				resp.Diagnostics.AddAttributeError(versionPath.AtName("handover_start_at"), "Missing Attribute Configuration", "One of handover_start_at or handover must be set.")
+			} else {
+				if _, err := time.Parse(time.RFC3339, version.HandoverStartAt.ValueString()); err != nil {
+					resp.Diagnostics.AddAttributeError(versionPath.AtName("handover_start_at"), "Invalid Timestamp", fmt.Sprintf("%q must be an RFC3339 timestamp, such as 2024-01-01T09:00:00Z.", version.HandoverStartAt.ValueString()))
+				}
+				// handover_start_at only anchors the handovers, so without any there's no way to
+				// know when the next shift starts.
+				if len(version.Handovers) == 0 {
+					resp.Diagnostics.AddAttributeError(versionPath.AtName("handovers"), "Missing Attribute Configuration", "At least one handover must be set alongside handover_start_at.")
+				}
+			}
+
+			for intervalIdx, interval := range version.WorkingIntervals {
+				intervalPath := versionPath.AtName("working_intervals").AtListIndex(intervalIdx)
+
+				start, startErr := parseWorkingIntervalTime(interval.Start.ValueString())
+				if startErr != nil {
+					resp.Diagnostics.AddAttributeError(intervalPath.AtName("start"), "Invalid Working Interval", fmt.Sprintf("%q must be a time in 24hr format, such as 09:00.", interval.Start.ValueString()))
+				}
+				end, endErr := parseWorkingIntervalTime(interval.End.ValueString())
+				if endErr != nil {
+					resp.Diagnostics.AddAttributeError(intervalPath.AtName("end"), "Invalid Working Interval", fmt.Sprintf("%q must be a time in 24hr format, such as 17:00.", interval.End.ValueString()))
+				}
+				if startErr == nil && endErr == nil && start.Equal(end) {
+					resp.Diagnostics.AddAttributeError(intervalPath.AtName("end"), "Invalid Working Interval", "The end of a working interval must be different to its start.")
+				}
+			}
+		}
+	}
+}
+
+func (r *IncidentScheduleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_schedule", "ModifyPlan")
+	defer done()
+
+	// Nothing to plan when we're being destroyed, but we may have been asked to refuse.
+	if req.Plan.Raw.IsNull() {
+		if r.protectDestructiveOperations {
+			var allowDestructiveOperations types.Bool
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("allow_destructive_operations"), &allowDestructiveOperations)...)
+			if !allowDestructiveOperations.ValueBool() {
+				resp.Diagnostics.AddError("Destructive Operation Prevented",
+					"This plan would destroy the schedule, but the provider sets protect_destructive_operations. If this is intended, apply allow_destructive_operations = true to this schedule before destroying it.")
+			}
+		}
+
+		return
+	}
+
+	// If anything isn't known yet, we'll be asked to plan again once it is.
+	if !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var data *IncidentScheduleResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Rotations == nil {
+		r.planClonedRotations(ctx, req, resp, data.CloneFrom)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	for rotationIdx, rotation := range data.Rotations {
+		for versionIdx, version := range rotation.Versions {
+			versionPath := path.Root("rotations").AtListIndex(rotationIdx).AtName("versions").AtListIndex(versionIdx)
+
+			// Generate the layers for any versions that gave a layer_count rather than listing
+			// them.
+			if !version.LayerCount.IsNull() {
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, versionPath.AtName("layers"), buildLayers(rotation.ID.ValueString(), version.LayerCount.ValueInt64()))...)
+			}
+
+			// Likewise, expand handover into the handover_start_at and handovers we send to the
+			// API. Otherwise handovers stays as configured.
+			if version.Handover != nil {
+				handover, err := buildHandoverSchedule(*version.Handover, buildTimezone(data.Timezone))
+				if err != nil {
+					resp.Diagnostics.AddAttributeError(versionPath.AtName("handover"), "Invalid Handover", err.Error())
+					continue
+				}
+
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, versionPath.AtName("handover_start_at"), handover.startAt)...)
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, versionPath.AtName("handovers"), handover.handovers)...)
+			} else {
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, versionPath.AtName("handovers"), version.Handovers)...)
+			}
+		}
+	}
+
+	r.guardHistoricalVersions(ctx, req, resp, time.Now())
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.warnRemovedUsersWithShifts(ctx, req, resp, data)
+
+	if !data.ValidateUsers.ValueBool() && !r.validateReferences {
+		return
+	}
+
+	exists := map[string]bool{}
+	for rotationIdx, rotation := range data.Rotations {
+		for versionIdx, version := range rotation.Versions {
+			for userIdx, user := range version.Users {
+				userPath := path.Root("rotations").AtListIndex(rotationIdx).
+					AtName("versions").AtListIndex(versionIdx).
+					AtName("users").AtListIndex(userIdx)
+
+				userExists, ok := exists[user.ValueString()]
+				if !ok {
+					var err error
+					userExists, err = r.userExists(ctx, user.ValueString())
+					if err != nil {
+						resp.Diagnostics.AddAttributeError(userPath, "Client Error", fmt.Sprintf("Unable to look up user %s, got error: %s", user.ValueString(), err))
+						continue
+					}
+
+					exists[user.ValueString()] = userExists
+				}
+
+				if !userExists {
+					resp.Diagnostics.AddAttributeError(userPath, "User not found", fmt.Sprintf("No user found with ID or email %q. They may have left the organisation, and should be removed from the rotation.", user.ValueString()))
+				}
+			}
+		}
 	}
 }
 
@@ -192,17 +513,43 @@ func (r *IncidentScheduleResource) Configure(ctx context.Context, req resource.C
 	}
 
 	r.client = client.Client
+	r.apiUsage = client.APIUsage
 	r.terraformVersion = client.TerraformVersion
+	r.protectDestructiveOperations = client.ProtectDestructiveOperations
+	r.adoptUnmanaged = client.AdoptUnmanaged
+	r.skipManagementAnnotations = client.SkipManagementAnnotations
+	r.validateReferences = client.ValidateReferences
 }
 
 func (r *IncidentScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_schedule", "Create")
+	defer done()
+
 	var data *IncidentScheduleResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	rotationArray, err := buildScheduleCreatePayload(data, resp)
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	userIDs, err := r.resolveUserEmails(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create schedule, got error: %s", err))
+		return
+	}
+
+	// We use the same time to decide which versions have expired when building the payload
+	// and the model, so the versions we leave out are the ones we put back into state.
+	now := time.Now()
+	rotationArray, err := buildScheduleCreatePayload(data, userIDs, now, resp)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create schedule, got error: %s", err))
 		return
@@ -210,18 +557,16 @@ func (r *IncidentScheduleResource) Create(ctx context.Context, req resource.Crea
 
 	result, err := r.client.SchedulesV2CreateWithResponse(ctx, client.SchedulesV2CreateJSONRequestBody{
 		Schedule: client.ScheduleCreatePayloadV2{
-			Annotations: &map[string]string{
-				"incident.io/terraform/version": r.terraformVersion,
-			},
-			Name:     data.Name.ValueStringPointer(),
-			Timezone: data.Timezone.ValueStringPointer(),
+			Annotations: lo.ToPtr(r.buildAnnotations(data)),
+			Name:        data.Name.ValueStringPointer(),
+			Timezone:    lo.ToPtr(buildTimezone(data.Timezone)),
 			Config: &client.ScheduleConfigCreatePayloadV2{
 				Rotations: &rotationArray,
 			},
 		},
 	})
 	if err == nil && result.StatusCode() >= 400 {
-		err = fmt.Errorf(string(result.Body))
+		err = newAPIError(result.StatusCode(), result.Body)
 	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create schedule, got error: %s", err))
@@ -229,41 +574,80 @@ func (r *IncidentScheduleResource) Create(ctx context.Context, req resource.Crea
 	}
 
 	tflog.Trace(ctx, fmt.Sprintf("created an incident schedule resource with id=%s", result.JSON201.Schedule.Id))
-	data = r.buildModel(result.JSON201.Schedule)
+	data = r.buildModel(result.JSON201.Schedule, data, now)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	// We claim the schedule once it's in state, so if this fails we won't lose track of it.
+	if !r.skipManagementAnnotations {
+		claimResource(ctx, r.client, result.JSON201.Schedule.Id, &resp.Diagnostics, client.ManagedResourceV2ResourceTypeSchedule, r.terraformVersion)
+	}
 }
 
 func (r *IncidentScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_schedule", "Read")
+	defer done()
+
 	var data *IncidentScheduleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	result, err := r.client.SchedulesV2ShowWithResponse(ctx, data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read schedule, got error: %s", err))
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if result.StatusCode() == 404 {
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	result, err := r.client.SchedulesV2ShowWithResponse(ctx, data.ID.ValueString())
+	if err == nil && result.StatusCode() == 404 {
 		resp.Diagnostics.AddWarning("Not Found", fmt.Sprintf("Unable to read schedule, got status code: %d", result.StatusCode()))
 		resp.State.RemoveResource(ctx)
 		return
 	}
+	if err == nil && result.StatusCode() >= 400 {
+		err = newAPIError(result.StatusCode(), result.Body)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read schedule, got error: %s", err))
+		return
+	}
 
-	data = r.buildModel(result.JSON200.Schedule)
+	data = r.buildModel(result.JSON200.Schedule, data, time.Now())
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *IncidentScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_schedule", "Update")
+	defer done()
+
 	var old *IncidentScheduleResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &old)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	rotationArray, err := buildScheduleUpdatePayload(old, resp)
+	updateTimeout, diags := old.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	userIDs, err := r.resolveUserEmails(ctx, old)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update schedule, got error: %s", err))
+		return
+	}
+
+	// As in Create, we decide which versions have expired once.
+	now := time.Now()
+	rotationArray, err := buildScheduleUpdatePayload(old, userIDs, now, resp)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update schedule, got error: %s", err))
 		return
@@ -271,53 +655,436 @@ func (r *IncidentScheduleResource) Update(ctx context.Context, req resource.Upda
 
 	result, err := r.client.SchedulesV2UpdateWithResponse(ctx, old.ID.ValueString(), client.SchedulesV2UpdateJSONRequestBody{
 		Schedule: client.ScheduleUpdatePayloadV2{
-			Annotations: &map[string]string{
-				"incident.io/terraform/version": r.terraformVersion,
-			},
-			Name:     old.Name.ValueStringPointer(),
-			Timezone: old.Timezone.ValueStringPointer(),
+			Annotations: lo.ToPtr(r.buildAnnotations(old)),
+			Name:        old.Name.ValueStringPointer(),
+			Timezone:    lo.ToPtr(buildTimezone(old.Timezone)),
 			Config: &client.ScheduleConfigUpdatePayloadV2{
 				Rotations: &rotationArray,
 			},
 		},
 	})
 	if err == nil && result.StatusCode() >= 400 {
-		err = fmt.Errorf(string(result.Body))
+		err = newAPIError(result.StatusCode(), result.Body)
 	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update schedule, got error: %s", err))
 		return
 	}
 
-	old = r.buildModel(result.JSON200.Schedule)
+	old = r.buildModel(result.JSON200.Schedule, old, now)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &old)...)
 }
 
 func (r *IncidentScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_schedule", "Delete")
+	defer done()
+
 	var data *IncidentScheduleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	_, err := r.client.SchedulesV2DestroyWithResponse(ctx, data.ID.ValueString())
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	result, err := r.client.SchedulesV2DestroyWithResponse(ctx, data.ID.ValueString())
+	if err == nil && result.StatusCode() == 404 {
+		// Someone has already deleted the schedule, which is what we wanted anyway.
+		return
+	}
+	if err == nil && result.StatusCode() >= 400 {
+		err = newAPIError(result.StatusCode(), result.Body)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete schedule, got error: %s", err))
 		return
 	}
 }
 
+// scheduleDefaultShiftWarningDays is how far ahead we look for shifts of users that are
+// being removed from a rotation, unless shift_warning_days says otherwise.
+const scheduleDefaultShiftWarningDays = 7
+
+// warnRemovedUsersWithShifts adds a warning for each user the plan removes from a rotation
+// who has shifts on that rotation soon, so accidentally removing someone from the rota is
+// visible when reviewing the plan.
+func (r *IncidentScheduleResource) warnRemovedUsersWithShifts(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, data *IncidentScheduleResourceModel) {
+	if req.State.Raw.IsNull() || data.Rotations == nil {
+		return
+	}
+
+	warningDays := int64OrDefault(data.ShiftWarningDays, scheduleDefaultShiftWarningDays)
+	if warningDays == 0 {
+		return
+	}
+
+	var state *IncidentScheduleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rotationUsers := func(rotations []Rotation) map[string][]string {
+		result := map[string][]string{}
+		for _, rotation := range rotations {
+			for _, version := range rotation.Versions {
+				for _, user := range version.Users {
+					result[rotation.ID.ValueString()] = append(result[rotation.ID.ValueString()], strings.ToLower(user.ValueString()))
+				}
+			}
+		}
+
+		return result
+	}
+
+	// Users are removed if they're no longer in any version of a rotation that still exists.
+	// Removing a whole rotation is obvious enough from the plan already.
+	plannedUsers := rotationUsers(data.Rotations)
+	removedUsers := map[string][]string{}
+	for rotationID, users := range rotationUsers(state.Rotations) {
+		if planned, ok := plannedUsers[rotationID]; ok {
+			if removed := lo.Uniq(lo.Without(users, planned...)); len(removed) > 0 {
+				removedUsers[rotationID] = removed
+			}
+		}
+	}
+	if len(removedUsers) == 0 {
+		return
+	}
+
+	now := time.Now()
+	result, err := r.client.SchedulesV2ListScheduleEntriesWithResponse(ctx, &client.SchedulesV2ListScheduleEntriesParams{
+		ScheduleId:       state.ID.ValueString(),
+		EntryWindowStart: &now,
+		EntryWindowEnd:   lo.ToPtr(now.AddDate(0, 0, int(warningDays))),
+	})
+	if err == nil && result.StatusCode() >= 400 {
+		err = newAPIError(result.StatusCode(), result.Body)
+	}
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to check upcoming shifts", fmt.Sprintf("Unable to list schedule entries to check whether removed users have upcoming shifts, got error: %s", err))
+		return
+	}
+
+	warned := map[string]bool{}
+	for _, entry := range result.JSON200.ScheduleEntries.Scheduled {
+		if entry.User == nil || entry.RotationId == nil {
+			continue
+		}
+
+		for _, user := range removedUsers[*entry.RotationId] {
+			if user != strings.ToLower(entry.User.Id) && user != strings.ToLower(lo.FromPtr(entry.User.Email)) {
+				continue
+			}
+
+			key := *entry.RotationId + "/" + user
+			if warned[key] {
+				continue
+			}
+			warned[key] = true
+
+			resp.Diagnostics.AddWarning(
+				"Removing user with upcoming shifts",
+				fmt.Sprintf("%s is being removed from rotation %s, but is scheduled to be on-call from %s. Their shifts will be reassigned when this is applied.", entry.User.Name, *entry.RotationId, entry.StartAt.Format(time.RFC3339)),
+			)
+		}
+	}
+}
+
+// guardHistoricalVersions refuses plans that change a rotation version which had already
+// been replaced by a later version as of now. Those versions describe who was on-call in
+// the past, which the API either refuses to change or rewrites, so changes should be made
+// by adding a new version instead.
+//
+// Versions are matched to those in state by their effective_from, so removing old versions
+// from the configuration, such as once they've passed version_retention_days, is fine.
+func (r *IncidentScheduleResource) guardHistoricalVersions(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, now time.Time) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state, plan *IncidentScheduleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(historicalVersionChanges(state, plan, now)...)
+}
+
+// historicalVersionChanges reports an error against each version in the plan that changes
+// a version in state which had been replaced as of now, for guardHistoricalVersions.
+func historicalVersionChanges(state, plan *IncidentScheduleResourceModel, now time.Time) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	priorRotations := lo.KeyBy(state.Rotations, func(rotation Rotation) string {
+		return rotation.ID.ValueString()
+	})
+	for rotationIdx, rotation := range plan.Rotations {
+		prior, ok := priorRotations[rotation.ID.ValueString()]
+		if !ok {
+			continue
+		}
+
+		for versionIdx, version := range rotation.Versions {
+			versionPath := path.Root("rotations").AtListIndex(rotationIdx).AtName("versions").AtListIndex(versionIdx)
+
+			_, priorIdx, ok := lo.FindIndexOf(prior.Versions, func(priorVersion RotationVersion) bool {
+				return timestampsEqual(priorVersion.EffectiveFrom, version.EffectiveFrom)
+			})
+			if !ok || priorIdx == len(prior.Versions)-1 {
+				continue // a new version, or the latest, which hasn't been replaced
+			}
+
+			replacedAt, err := time.Parse(time.RFC3339, prior.Versions[priorIdx+1].EffectiveFrom.ValueString())
+			if err != nil || !replacedAt.Before(now) {
+				continue // still in effect, or yet to take effect
+			}
+
+			changed := rotationVersionChanges(prior.Versions[priorIdx], version)
+			if versionIdx == len(rotation.Versions)-1 || !timestampsEqual(prior.Versions[priorIdx+1].EffectiveFrom, rotation.Versions[versionIdx+1].EffectiveFrom) {
+				changed = append(changed, "the effective_from of the version that replaced it")
+			}
+			if len(changed) == 0 {
+				continue
+			}
+
+			diags.AddAttributeError(versionPath, "Historical Version Changed",
+				fmt.Sprintf("This version of rotation %s was replaced at %s, so it only describes who was on-call in the past, but this plan changes %s. Leave it as it is, and add a new version with an effective_from in the future for the change instead.",
+					rotation.ID.ValueString(), replacedAt.Format(time.RFC3339), strings.Join(changed, ", ")))
+		}
+	}
+
+	return diags
+}
+
+// rotationVersionChanges lists the attributes that differ between two versions of a
+// rotation, ignoring any that aren't known yet.
+func rotationVersionChanges(prior, planned RotationVersion) []string {
+	changed := []string{}
+	if !planned.HandoverStartAt.IsUnknown() && !timestampsEqual(prior.HandoverStartAt, planned.HandoverStartAt) {
+		changed = append(changed, "handover_start_at")
+	}
+	if !reflect.DeepEqual(prior.Handovers, planned.Handovers) {
+		changed = append(changed, "handovers")
+	}
+	if !reflect.DeepEqual(prior.Layers, planned.Layers) {
+		changed = append(changed, "layers")
+	}
+	if !reflect.DeepEqual(prior.Users, planned.Users) {
+		changed = append(changed, "users")
+	}
+	if !reflect.DeepEqual(prior.WorkingIntervals, planned.WorkingIntervals) {
+		changed = append(changed, "working_intervals")
+	}
+
+	return changed
+}
+
+// timestampsEqual returns true if both are null, or are the same instant.
+func timestampsEqual(left, right types.String) bool {
+	if left.IsNull() || right.IsNull() {
+		return left.IsNull() && right.IsNull()
+	}
+
+	leftTime, leftErr := time.Parse(time.RFC3339, left.ValueString())
+	rightTime, rightErr := time.Parse(time.RFC3339, right.ValueString())
+	if leftErr != nil || rightErr != nil {
+		return left.ValueString() == right.ValueString()
+	}
+
+	return leftTime.Equal(rightTime)
+}
+
+// knownStringPointer returns a pointer to the value of a string, or nil if it's null or
+// not yet known, such as the IDs of cloned rotations that the API will assign.
+func knownStringPointer(value types.String) *string {
+	if value.IsUnknown() {
+		return nil
+	}
+
+	return value.ValueStringPointer()
+}
+
+// currentVersionIndex returns the index of the version of a rotation that's in effect at
+// the given time, or the first version if none of them are yet.
+func currentVersionIndex(versions []RotationVersion, now time.Time) int {
+	current := 0
+	for idx, version := range versions {
+		effectiveFrom, err := time.Parse(time.RFC3339, version.EffectiveFrom.ValueString())
+		if version.EffectiveFrom.IsNull() || (err == nil && !effectiveFrom.After(now)) {
+			current = idx
+		}
+	}
+
+	return current
+}
+
+// planClonedRotations plans the rotations of a schedule that uses clone_from. When we're
+// creating the schedule, these are copied from the schedule we're cloning, so the plan
+// shows exactly what we'll create. After that we keep the rotations we already have.
+func (r *IncidentScheduleResource) planClonedRotations(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, cloneFrom types.String) {
+	var planned types.List
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("rotations"), &planned)...)
+	if resp.Diagnostics.HasError() || !planned.IsUnknown() {
+		return
+	}
+
+	var rotations []Rotation
+	if req.State.Raw.IsNull() {
+		scheduleID, err := r.findScheduleID(ctx, cloneFrom.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("clone_from"), "Client Error", fmt.Sprintf("Unable to find schedule to clone, got error: %s", err))
+			return
+		}
+
+		result, err := r.client.SchedulesV2ShowWithResponse(ctx, scheduleID)
+		if err == nil && result.StatusCode() >= 400 {
+			err = newAPIError(result.StatusCode(), result.Body)
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("clone_from"), "Client Error", fmt.Sprintf("Unable to read schedule to clone, got error: %s", err))
+			return
+		}
+
+		// The rotations we clone belong to the new schedule, so we leave it to the API to
+		// assign them IDs of their own. We create the versions of a rotation together by
+		// giving them the same ID, so without one we clone only the version in effect now.
+		now := time.Now()
+		rotations = r.buildModel(result.JSON200.Schedule, nil, now).Rotations
+		for idx, rotation := range rotations {
+			version := rotation.Versions[currentVersionIndex(rotation.Versions, now)]
+			for layerIdx := range version.Layers {
+				version.Layers[layerIdx].ID = types.StringUnknown()
+			}
+
+			rotations[idx].ID = types.StringUnknown()
+			rotations[idx].Versions = []RotationVersion{version}
+		}
+	} else {
+		var state *IncidentScheduleResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		rotations = state.Rotations
+	}
+
+	// Who's on-call will be refreshed when we apply.
+	for idx := range rotations {
+		rotations[idx].OnCallUserIDs = types.ListUnknown(types.StringType)
+		rotations[idx].NextHandoverAt = types.StringUnknown()
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("rotations"), rotations)...)
+}
+
+// ImportState accepts either the ID or the name of a schedule, as the ID of schedules
+// created in the dashboard can be awkward to find.
 func (r *IncidentScheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	claimResource(ctx, r.client, req, resp, client.ManagedResourceV2ResourceTypeSchedule, r.terraformVersion)
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	ctx, done := r.apiUsage.track(ctx, "incident_schedule", "ImportState")
+	defer done()
+
+	scheduleID, err := r.findScheduleID(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import schedule, got error: %s", err))
+		return
+	}
+
+	if !r.adoptUnmanaged {
+		result, err := r.client.SchedulesV2ShowWithResponse(ctx, scheduleID)
+		if err == nil && result.StatusCode() >= 400 {
+			err = newAPIError(result.StatusCode(), result.Body)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import schedule, got error: %s", err))
+			return
+		}
+
+		// Schedules don't tell us how they're managed, but only those created or imported by
+		// terraform have the annotation we set.
+		_, managedByTerraform := result.JSON200.Schedule.Annotations["incident.io/terraform/version"]
+		refuseUnmanagedImport(&resp.Diagnostics, client.ManagedResourceV2ResourceTypeSchedule, scheduleID, !managedByTerraform)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !r.skipManagementAnnotations {
+		claimResource(ctx, r.client, scheduleID, &resp.Diagnostics, client.ManagedResourceV2ResourceTypeSchedule, r.terraformVersion)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), scheduleID)...)
 }
 
-func buildScheduleCreatePayload(data *IncidentScheduleResourceModel, resp *resource.CreateResponse) ([]client.ScheduleRotationCreatePayloadV2, error) {
+// findScheduleID returns the ID of the schedule with the given ID or, failing that, the
+// only schedule with the given name.
+func (r *IncidentScheduleResource) findScheduleID(ctx context.Context, idOrName string) (string, error) {
+	result, err := r.client.SchedulesV2ShowWithResponse(ctx, idOrName)
+	if err != nil {
+		return "", err
+	}
+	if result.StatusCode() == 200 {
+		return idOrName, nil
+	}
+
+	matches := []string{}
+	err = client.Paginate(ctx, func(ctx context.Context, after *string) (*client.Page[client.ScheduleV2], error) {
+		result, err := r.client.SchedulesV2ListWithResponse(ctx, &client.SchedulesV2ListParams{
+			PageSize: lo.ToPtr(int64(100)),
+			After:    after,
+		})
+		if err == nil && result.StatusCode() >= 400 {
+			err = newAPIError(result.StatusCode(), result.Body)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		page := &client.Page[client.ScheduleV2]{Items: result.JSON200.Schedules}
+		if result.JSON200.PaginationMeta != nil {
+			page.After = result.JSON200.PaginationMeta.After
+		}
+
+		return page, nil
+	}, func(schedules []client.ScheduleV2) error {
+		for _, schedule := range schedules {
+			if schedule.Name == idOrName {
+				matches = append(matches, schedule.Id)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "listing schedules")
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no schedule found with ID or name %q", idOrName)
+	case 1:
+		tflog.Debug(ctx, fmt.Sprintf("resolved schedule name %q to id=%s", idOrName, matches[0]))
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("found %d schedules named %q, please import using the ID instead", len(matches), idOrName)
+	}
+}
+
+func buildScheduleCreatePayload(data *IncidentScheduleResourceModel, userIDs map[string]string, now time.Time, resp *resource.CreateResponse) ([]client.ScheduleRotationCreatePayloadV2, error) {
 	rotationArray := make([]client.ScheduleRotationCreatePayloadV2, 0, len(data.Rotations))
 	for _, rotation := range data.Rotations {
-		for _, version := range rotation.Versions {
+		for _, version := range rotation.Versions[expiredVersionCount(rotation.Versions, data.VersionRetentionDays, now):] {
 			workingIntervals := make([]client.ScheduleRotationWorkingIntervalV2, 0, len(version.WorkingIntervals))
-			for _, workingInterval := range version.WorkingIntervals {
+			for _, workingInterval := range splitOvernightWorkingIntervals(version.WorkingIntervals) {
 				workingIntervalWeekday := client.ScheduleRotationWorkingIntervalV2Weekday(workingInterval.Day.ValueString())
 				workingIntervals = append(workingIntervals, client.ScheduleRotationWorkingIntervalV2{
 					StartTime: workingInterval.Start.ValueString(),
@@ -329,7 +1096,7 @@ func buildScheduleCreatePayload(data *IncidentScheduleResourceModel, resp *resou
 			layers := make([]client.ScheduleLayerCreatePayloadV2, 0, len(version.Layers))
 			for _, layer := range version.Layers {
 				layers = append(layers, client.ScheduleLayerCreatePayloadV2{
-					Id:   layer.ID.ValueStringPointer(),
+					Id:   knownStringPointer(layer.ID),
 					Name: layer.Name.ValueString(),
 				})
 			}
@@ -342,10 +1109,10 @@ func buildScheduleCreatePayload(data *IncidentScheduleResourceModel, resp *resou
 
 			effectiveFrom := buildEffectiveFrom(resp.Diagnostics, version.EffectiveFrom)
 			handovers := buildHandoversArray(version.Handovers)
-			users := buildUsersArray(version.Users)
+			users := buildUsersArray(version.Users, userIDs)
 
 			rotationArray = append(rotationArray, client.ScheduleRotationCreatePayloadV2{
-				Id:              rotation.ID.ValueStringPointer(),
+				Id:              knownStringPointer(rotation.ID),
 				Name:            rotation.Name.ValueString(),
 				HandoverStartAt: &handoverStartAt,
 				EffectiveFrom:   effectiveFrom,
@@ -359,12 +1126,12 @@ func buildScheduleCreatePayload(data *IncidentScheduleResourceModel, resp *resou
 	return rotationArray, nil
 }
 
-func buildScheduleUpdatePayload(data *IncidentScheduleResourceModel, resp *resource.UpdateResponse) ([]client.ScheduleRotationUpdatePayloadV2, error) {
+func buildScheduleUpdatePayload(data *IncidentScheduleResourceModel, userIDs map[string]string, now time.Time, resp *resource.UpdateResponse) ([]client.ScheduleRotationUpdatePayloadV2, error) {
 	rotationArray := make([]client.ScheduleRotationUpdatePayloadV2, 0, len(data.Rotations))
 	for _, rotation := range data.Rotations {
-		for _, version := range rotation.Versions {
+		for _, version := range rotation.Versions[expiredVersionCount(rotation.Versions, data.VersionRetentionDays, now):] {
 			workingIntervals := make([]client.ScheduleRotationWorkingIntervalUpdatePayloadV2, 0, len(version.WorkingIntervals))
-			for _, workingInterval := range version.WorkingIntervals {
+			for _, workingInterval := range splitOvernightWorkingIntervals(version.WorkingIntervals) {
 				workingIntervalWeekday := client.ScheduleRotationWorkingIntervalUpdatePayloadV2Weekday(workingInterval.Day.ValueString())
 				workingIntervals = append(workingIntervals, client.ScheduleRotationWorkingIntervalUpdatePayloadV2{
 					StartTime: workingInterval.Start.ValueStringPointer(),
@@ -376,7 +1143,7 @@ func buildScheduleUpdatePayload(data *IncidentScheduleResourceModel, resp *resou
 			layers := make([]client.ScheduleLayerV2, 0, len(version.Layers))
 			for _, layer := range version.Layers {
 				layers = append(layers, client.ScheduleLayerV2{
-					Id:   layer.ID.ValueStringPointer(),
+					Id:   knownStringPointer(layer.ID),
 					Name: layer.Name.ValueStringPointer(),
 				})
 			}
@@ -389,10 +1156,10 @@ func buildScheduleUpdatePayload(data *IncidentScheduleResourceModel, resp *resou
 
 			effectiveFrom := buildEffectiveFrom(resp.Diagnostics, version.EffectiveFrom)
 			handovers := buildHandoversArray(version.Handovers)
-			users := buildUsersArray(version.Users)
+			users := buildUsersArray(version.Users, userIDs)
 
 			rotationArray = append(rotationArray, client.ScheduleRotationUpdatePayloadV2{
-				Id:              rotation.ID.ValueStringPointer(),
+				Id:              knownStringPointer(rotation.ID),
 				Name:            rotation.Name.ValueStringPointer(),
 				HandoverStartAt: &handoverStartAt,
 				EffectiveFrom:   effectiveFrom,
@@ -406,15 +1173,239 @@ func buildScheduleUpdatePayload(data *IncidentScheduleResourceModel, resp *resou
 	return rotationArray, nil
 }
 
-// buildUsersArray converts a list of user IDs to a list of user references.
-func buildUsersArray(users []types.String) []client.UserReferencePayloadV1 {
+// buildUsersArray converts a list of user IDs or emails to a list of user references,
+// using userIDs to look up the ID of any user given by email.
+func buildUsersArray(users []types.String, userIDs map[string]string) []client.UserReferencePayloadV1 {
 	return lo.Map(users, func(user types.String, _ int) client.UserReferencePayloadV1 {
+		if userID, ok := userIDs[strings.ToLower(user.ValueString())]; ok {
+			return client.UserReferencePayloadV1{
+				Id: &userID,
+			}
+		}
+
 		return client.UserReferencePayloadV1{
 			Id: user.ValueStringPointer(),
 		}
 	})
 }
 
+// userExists returns true if there is a user with the given ID or email.
+func (r *IncidentScheduleResource) userExists(ctx context.Context, user string) (bool, error) {
+	if isUserEmail(user) {
+		result, err := r.client.UsersV2ListWithResponse(ctx, &client.UsersV2ListParams{
+			Email: &user,
+		})
+		if err == nil && result.StatusCode() >= 400 {
+			err = newAPIError(result.StatusCode(), result.Body)
+		}
+		if err != nil {
+			return false, err
+		}
+
+		return len(result.JSON200.Users) == 1, nil
+	}
+
+	result, err := r.client.UsersV2ShowWithResponse(ctx, user)
+	if err == nil && result.StatusCode() == 404 {
+		return false, nil
+	}
+	if err == nil && result.StatusCode() >= 400 {
+		err = newAPIError(result.StatusCode(), result.Body)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// isUserEmail returns true if a rotation user has been given as an email address rather
+// than an incident.io user ID.
+func isUserEmail(user string) bool {
+	return strings.Contains(user, "@")
+}
+
+// resolveUserEmails looks up every user given by email across all rotations, returning a
+// map of lowercased email to user ID. If any email doesn't match a user, we return an
+// error listing all of them so they can be fixed at once.
+func (r *IncidentScheduleResource) resolveUserEmails(ctx context.Context, data *IncidentScheduleResourceModel) (map[string]string, error) {
+	emails := []string{}
+	for _, rotation := range data.Rotations {
+		for _, version := range rotation.Versions {
+			for _, user := range version.Users {
+				if isUserEmail(user.ValueString()) {
+					emails = append(emails, strings.ToLower(user.ValueString()))
+				}
+			}
+		}
+	}
+
+	userIDs := map[string]string{}
+	unknownEmails := []string{}
+	for _, email := range lo.Uniq(emails) {
+		email := email
+		result, err := r.client.UsersV2ListWithResponse(ctx, &client.UsersV2ListParams{
+			Email: &email,
+		})
+		if err == nil && result.StatusCode() >= 400 {
+			err = newAPIError(result.StatusCode(), result.Body)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("looking up user with email %s", email))
+		}
+
+		if len(result.JSON200.Users) != 1 {
+			unknownEmails = append(unknownEmails, email)
+			continue
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("resolved user email %s to id=%s", email, result.JSON200.Users[0].Id))
+		userIDs[email] = result.JSON200.Users[0].Id
+	}
+
+	if len(unknownEmails) > 0 {
+		sort.Strings(unknownEmails)
+		return nil, fmt.Errorf("no user found for the emails: %s", strings.Join(unknownEmails, ", "))
+	}
+
+	return userIDs, nil
+}
+
+const (
+	// workingIntervalTimeFormat is the 24hr format used for the start and end of working
+	// intervals.
+	workingIntervalTimeFormat = "15:04"
+
+	// workingIntervalEndOfDay is the latest time we can express in that format, which ends
+	// the first half of an overnight interval. The API has no way to express midnight at the
+	// end of a day, so this leaves the last minute of the day uncovered.
+	workingIntervalEndOfDay = "23:59"
+)
+
+// parseWorkingIntervalTime parses the start or end of a working interval, so that the times
+// we validate are the same ones we can send to the API.
+func parseWorkingIntervalTime(value string) (time.Time, error) {
+	return time.Parse(workingIntervalTimeFormat, value)
+}
+
+// scheduleWeekdays lists the days of the week in order, starting from Monday, so we can
+// work out the day after another or how far into the week a day is. We don't rely on the
+// order of the weekday enum in the API schema, which only promises which values are valid.
+var scheduleWeekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// splitOvernightWorkingIntervals splits any working intervals that end before they start,
+// such as 22:00 to 06:00, into an interval that runs until the end of the day and another
+// that runs from the start of the next day. Intervals that don't cross midnight are left
+// as they are.
+func splitOvernightWorkingIntervals(intervals []WorkingInterval) []WorkingInterval {
+	if intervals == nil {
+		return nil
+	}
+
+	result := make([]WorkingInterval, 0, len(intervals))
+	for _, interval := range intervals {
+		start, startErr := parseWorkingIntervalTime(interval.Start.ValueString())
+		end, endErr := parseWorkingIntervalTime(interval.End.ValueString())
+		dayIdx := lo.IndexOf(scheduleWeekdays, interval.Day.ValueString())
+		if startErr != nil || endErr != nil || dayIdx < 0 || !end.Before(start) {
+			result = append(result, interval)
+			continue
+		}
+
+		result = append(result, WorkingInterval{
+			Start: interval.Start,
+			End:   types.StringValue(workingIntervalEndOfDay),
+			Day:   interval.Day,
+		})
+		if end.Format(workingIntervalTimeFormat) != "00:00" {
+			result = append(result, WorkingInterval{
+				Start: types.StringValue("00:00"),
+				End:   interval.End,
+				Day:   types.StringValue(scheduleWeekdays[(dayIdx+1)%len(scheduleWeekdays)]),
+			})
+		}
+	}
+
+	return result
+}
+
+// handoverReferenceDate is the Monday we anchor handovers configured using handover to,
+// so the handover_start_at we generate is the same every time we plan.
+var handoverReferenceDate = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+var handoverEveryPattern = regexp.MustCompile(`^([1-9][0-9]*)([hdw])$`)
+
+type expandedHandover struct {
+	startAt   types.String
+	handovers []Handover
+}
+
+// buildHandoverSchedule converts a handover into the handover_start_at and handovers the
+// API expects, anchoring handovers to a fixed date in the schedule's timezone.
+func buildHandoverSchedule(handover HandoverSchedule, timezone string) (*expandedHandover, error) {
+	match := handoverEveryPattern.FindStringSubmatch(handover.Every.ValueString())
+	if match == nil {
+		return nil, fmt.Errorf("every must be a number of hours, days or weeks such as 12h, 1d or 2w, got %q", handover.Every.ValueString())
+	}
+	interval, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("every must be a number of hours, days or weeks such as 12h, 1d or 2w, got %q", handover.Every.ValueString())
+	}
+	intervalType := map[string]client.ScheduleRotationHandoverV2IntervalType{
+		"h": client.Hourly,
+		"d": client.Daily,
+		"w": client.Weekly,
+	}[match[2]]
+
+	if !handover.On.IsNull() && intervalType != client.Weekly {
+		return nil, fmt.Errorf("on can only be used with weekly handovers")
+	}
+
+	at, err := time.Parse(workingIntervalTimeFormat, lo.Ternary(handover.At.IsNull(), "09:00", handover.At.ValueString()))
+	if err != nil {
+		return nil, fmt.Errorf("at must be a time in 24hr format such as 09:00, got %q", handover.At.ValueString())
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	dayOffset := lo.IndexOf(scheduleWeekdays, lo.Ternary(handover.On.IsNull(), "monday", handover.On.ValueString()))
+	if dayOffset < 0 {
+		return nil, fmt.Errorf("on must be a day of the week such as monday, got %q", handover.On.ValueString())
+	}
+
+	startAt := time.Date(
+		handoverReferenceDate.Year(), handoverReferenceDate.Month(), handoverReferenceDate.Day()+dayOffset,
+		at.Hour(), at.Minute(), 0, 0, location,
+	)
+
+	return &expandedHandover{
+		startAt: types.StringValue(startAt.Format(time.RFC3339)),
+		handovers: []Handover{
+			{
+				Interval:     types.Int64Value(interval),
+				IntervalType: types.StringValue(string(intervalType)),
+			},
+		},
+	}, nil
+}
+
+// buildLayers generates layers for a rotation version that gave a layer_count, with IDs
+// derived from the rotation so they're the same for every version of it.
+func buildLayers(rotationID string, count int64) []Layer {
+	layers := make([]Layer, 0, count)
+	for n := int64(1); n <= count; n++ {
+		layers = append(layers, Layer{
+			ID:   types.StringValue(fmt.Sprintf("%s-layer-%d", rotationID, n)),
+			Name: types.StringValue(fmt.Sprintf("Layer %d", n)),
+		})
+	}
+
+	return layers
+}
+
 // buildHandoversArray converts a list of handovers to a list of handover references.
 func buildHandoversArray(handovers []Handover) []client.ScheduleRotationHandoverV2 {
 	clientHandovers := lo.Map(handovers, func(handover Handover, _ int) client.ScheduleRotationHandoverV2 {
@@ -427,6 +1418,40 @@ func buildHandoversArray(handovers []Handover) []client.ScheduleRotationHandover
 	return clientHandovers
 }
 
+// scheduleReservedAnnotationPrefix is used by annotations that incident.io sets, such as
+// the version of terraform that last applied the schedule.
+const scheduleReservedAnnotationPrefix = "incident.io/"
+
+// buildAnnotations merges the configured annotations with those the provider sets, unless
+// it's been configured to skip them.
+func (r *IncidentScheduleResource) buildAnnotations(data *IncidentScheduleResourceModel) map[string]string {
+	annotations := map[string]string{}
+	for key, value := range data.Annotations.Elements() {
+		if value, ok := value.(types.String); ok {
+			annotations[key] = value.ValueString()
+		}
+	}
+
+	if managed := managementAnnotations(r.terraformVersion, r.skipManagementAnnotations); managed != nil {
+		for key, value := range *managed {
+			annotations[key] = value
+		}
+	}
+
+	return annotations
+}
+
+// buildTimezone returns the canonical name for the configured timezone. The validator
+// has already checked it's valid, so we fall back to sending it as-is.
+func buildTimezone(timezone types.String) string {
+	canonical, err := canonicalTimezone(timezone.ValueString())
+	if err != nil {
+		return timezone.ValueString()
+	}
+
+	return canonical
+}
+
 // buildEffectiveFrom converts a string to a time.Time pointer.
 func buildEffectiveFrom(diagnostics diag.Diagnostics, effectiveFrom types.String) *time.Time {
 	if effectiveFrom.IsNull() {
@@ -445,7 +1470,12 @@ func buildEffectiveFrom(diagnostics diag.Diagnostics, effectiveFrom types.String
 // buildModel converts a schedule from the API to a resource model
 // this involves taking schedule rotations, grouping them by ID,
 // extracting the shared data, and then building the nested structure.
-func (r *IncidentScheduleResource) buildModel(schedule client.ScheduleV2) *IncidentScheduleResourceModel {
+//
+// The prior model, if we have one, is used to keep users that were configured by email
+// as emails, rather than replacing them with the ID the API returns, and to keep a
+// timezone that was configured using an alias. Timestamps are kept as they were written
+// too, provided they're the same instant the API returned.
+func (r *IncidentScheduleResource) buildModel(schedule client.ScheduleV2, prior *IncidentScheduleResourceModel, now time.Time) *IncidentScheduleResourceModel {
 	rotationsGroupedByID := lo.GroupBy(schedule.Config.Rotations, func(rotation client.ScheduleRotationV2) string {
 		return rotation.Id
 	})
@@ -464,15 +1494,77 @@ func (r *IncidentScheduleResource) buildModel(schedule client.ScheduleV2) *Incid
 
 	rotationNames = lo.Uniq(rotationNames)
 
+	// The API may return rotations in a different order to the one they were configured in,
+	// so we keep the prior order where we can to avoid diffs from ordering alone. Any
+	// rotations the prior model doesn't know about go at the end, in the order received.
+	if prior != nil {
+		// Cloned rotations have no ID until they're created, so we match those by name.
+		priorOrder, priorOrderByName := map[string]int{}, map[string]int{}
+		for idx, rotation := range prior.Rotations {
+			if rotation.ID.IsUnknown() {
+				priorOrderByName[rotation.Name.ValueString()] = idx
+			} else {
+				priorOrder[rotation.ID.ValueString()] = idx
+			}
+		}
+		orderOf := func(rotation RotationName) (int, bool) {
+			if order, ok := priorOrder[rotation.ID]; ok {
+				return order, true
+			}
+			order, ok := priorOrderByName[rotation.Name]
+			return order, ok
+		}
+
+		sort.SliceStable(rotationNames, func(i, j int) bool {
+			iOrder, iOK := orderOf(rotationNames[i])
+			jOrder, jOK := orderOf(rotationNames[j])
+			if iOK && jOK {
+				return iOrder < jOrder
+			}
+
+			return iOK && !jOK
+		})
+	}
+
+	timezone := types.StringValue(schedule.Timezone)
+	if prior != nil && timezonesEquivalent(prior.Timezone.ValueString(), schedule.Timezone) {
+		timezone = prior.Timezone
+	}
+
+	// These are only known to terraform, so we carry them over from the prior model.
+	versionRetentionDays, validateUsers, cloneFrom, shiftWarningDays, allowDestructiveOperations := types.Int64Null(), types.BoolNull(), types.StringNull(), types.Int64Null(), types.BoolNull()
+	var operationTimeouts timeouts.Value
+	if prior != nil {
+		versionRetentionDays, validateUsers, cloneFrom, shiftWarningDays, allowDestructiveOperations = prior.VersionRetentionDays, prior.ValidateUsers, prior.CloneFrom, prior.ShiftWarningDays, prior.AllowDestructiveOperations
+		operationTimeouts = prior.Timeouts
+	}
+
+	// Annotations that incident.io sets aren't managed by the user, so we leave them out.
+	annotationValues := map[string]attr.Value{}
+	for key, value := range schedule.Annotations {
+		if !strings.HasPrefix(key, scheduleReservedAnnotationPrefix) {
+			annotationValues[key] = types.StringValue(value)
+		}
+	}
+	annotations := types.MapValueMust(types.StringType, annotationValues)
+	if len(annotationValues) == 0 && (prior == nil || prior.Annotations.IsNull()) {
+		annotations = types.MapNull(types.StringType)
+	}
+
 	return &IncidentScheduleResourceModel{
-		Name:     types.StringValue(schedule.Name),
-		ID:       types.StringValue(schedule.Id),
-		Timezone: types.StringValue(schedule.Timezone),
+		Annotations: annotations,
+		Name:        types.StringValue(schedule.Name),
+		ID:          types.StringValue(schedule.Id),
+		Timezone:    timezone,
 		Rotations: lo.Map(rotationNames, func(rotation RotationName, _ int) Rotation {
+			expired := prior.expiredVersions(rotation.ID, len(rotationsGroupedByID[rotation.ID]), now)
+
 			newRotation := Rotation{
 				ID:   types.StringValue(rotation.ID),
 				Name: types.StringValue(rotation.Name),
-				Versions: lo.Map(rotationsGroupedByID[rotation.ID], func(rotation client.ScheduleRotationV2, idx int) RotationVersion {
+				Versions: append(expired, lo.Map(rotationsGroupedByID[rotation.ID], func(rotation client.ScheduleRotationV2, idx int) RotationVersion {
+					priorVersion := prior.rotationVersion(rotation.Id, len(expired)+idx)
+
 					var workingIntervals []WorkingInterval
 					if rotation.WorkingInterval != nil {
 						workingIntervals = lo.Map(*rotation.WorkingInterval, func(interval client.ScheduleRotationWorkingIntervalV2, _ int) WorkingInterval {
@@ -485,6 +1577,12 @@ func (r *IncidentScheduleResource) buildModel(schedule client.ScheduleV2) *Incid
 						})
 					}
 
+					// If we split overnight intervals from the prior model, and they're unchanged, keep
+					// them as they were written.
+					if priorVersion != nil && reflect.DeepEqual(splitOvernightWorkingIntervals(priorVersion.WorkingIntervals), workingIntervals) {
+						workingIntervals = priorVersion.WorkingIntervals
+					}
+
 					layers := lo.Map(rotation.Layers, func(layer client.ScheduleLayerV2, _ int) Layer {
 						return Layer{
 							ID:   types.StringPointerValue(layer.Id),
@@ -502,22 +1600,45 @@ func (r *IncidentScheduleResource) buildModel(schedule client.ScheduleV2) *Incid
 
 					users := []types.String{}
 					if rotation.Users != nil {
-						users = lo.Map(lo.FromPtr(rotation.Users), func(user client.UserV1, _ int) types.String {
+						users = lo.Map(lo.FromPtr(rotation.Users), func(user client.UserV1, idx int) types.String {
+							if priorVersion != nil && idx < len(priorVersion.Users) {
+								priorUser := priorVersion.Users[idx].ValueString()
+								if isUserEmail(priorUser) && strings.EqualFold(priorUser, lo.FromPtr(user.Email)) {
+									return priorVersion.Users[idx]
+								}
+							}
+
 							return types.StringValue(user.Id)
 						})
 					}
 
+					var priorEffectiveFrom, priorHandoverStartAt types.String
+					if priorVersion != nil {
+						priorEffectiveFrom, priorHandoverStartAt = priorVersion.EffectiveFrom, priorVersion.HandoverStartAt
+					}
+
 					var effectiveFrom types.String
 					if rotation.EffectiveFrom != nil {
-						effectiveFromValue := rotation.EffectiveFrom.Format(time.RFC3339)
-						effectiveFrom = types.StringValue(effectiveFromValue)
+						effectiveFrom = buildTimestamp(*rotation.EffectiveFrom, priorEffectiveFrom)
 					} else {
 						effectiveFrom = types.StringNull()
 					}
 
-					handoverStartAt := types.StringValue(rotation.HandoverStartAt.Format(time.RFC3339))
+					handoverStartAt := buildTimestamp(rotation.HandoverStartAt, priorHandoverStartAt)
+
+					layerCount := types.Int64Null()
+					if priorVersion != nil {
+						layerCount = priorVersion.LayerCount
+					}
+
+					var handover *HandoverSchedule
+					if priorVersion != nil {
+						handover = priorVersion.Handover
+					}
 
 					return RotationVersion{
+						Handover:         handover,
+						LayerCount:       layerCount,
 						EffectiveFrom:    effectiveFrom,
 						Handovers:        handovers,
 						Users:            users,
@@ -525,9 +1646,121 @@ func (r *IncidentScheduleResource) buildModel(schedule client.ScheduleV2) *Incid
 						Layers:           layers,
 						HandoverStartAt:  handoverStartAt,
 					}
-				}),
+				})...),
 			}
+			newRotation.OnCallUserIDs, newRotation.NextHandoverAt = buildRotationShifts(schedule.CurrentShifts, rotation.ID)
+
 			return newRotation
 		}),
+		VersionRetentionDays:       versionRetentionDays,
+		ValidateUsers:              validateUsers,
+		CloneFrom:                  cloneFrom,
+		ShiftWarningDays:           shiftWarningDays,
+		AllowDestructiveOperations: allowDestructiveOperations,
+		Timeouts:                   operationTimeouts,
+	}
+}
+
+// buildTimestamp formats a timestamp from the API as RFC3339, unless the prior value is
+// the same instant written differently, such as with a +00:00 offset rather than Z, or
+// with fractional seconds the API doesn't keep. In that case we keep the prior value, so
+// equivalent timestamps don't produce a diff on every plan.
+func buildTimestamp(value time.Time, prior types.String) types.String {
+	if !prior.IsNull() && !prior.IsUnknown() {
+		priorValue, err := time.Parse(time.RFC3339, prior.ValueString())
+		if err == nil && priorValue.Truncate(time.Second).Equal(value.Truncate(time.Second)) {
+			return prior
+		}
+	}
+
+	return types.StringValue(value.Format(time.RFC3339))
+}
+
+// expiredVersionCount returns how many versions, from the start of a rotation, were
+// replaced by a later version more than retentionDays ago. These are no longer sent to
+// the API.
+func expiredVersionCount(versions []RotationVersion, retentionDays types.Int64, now time.Time) int {
+	if retentionDays.IsNull() || retentionDays.IsUnknown() || len(versions) == 0 {
+		return 0
 	}
+
+	cutoff := now.AddDate(0, 0, -int(retentionDays.ValueInt64()))
+	for idx := 0; idx < len(versions)-1; idx++ {
+		replacedAt, err := time.Parse(time.RFC3339, versions[idx+1].EffectiveFrom.ValueString())
+		if err != nil || !replacedAt.Before(cutoff) {
+			return idx
+		}
+	}
+
+	// We always keep the latest version, as it's never been replaced.
+	return len(versions) - 1
+}
+
+// expiredVersions returns the versions of a rotation that the prior model had stopped
+// sending to the API, so we can keep them in state until they're removed from the
+// configuration. We only do this if the API has exactly the versions that remained.
+//
+// When building the model after a create or update, now must be the time used to build
+// its payload, so we restore the same versions that were left out of it.
+func (m *IncidentScheduleResourceModel) expiredVersions(rotationID string, remaining int, now time.Time) []RotationVersion {
+	if m == nil {
+		return []RotationVersion{}
+	}
+
+	for _, rotation := range m.Rotations {
+		if rotation.ID.ValueString() != rotationID {
+			continue
+		}
+
+		count := expiredVersionCount(rotation.Versions, m.VersionRetentionDays, now)
+		if count+remaining != len(rotation.Versions) {
+			return []RotationVersion{}
+		}
+
+		return append([]RotationVersion{}, rotation.Versions[:count]...)
+	}
+
+	return []RotationVersion{}
+}
+
+// buildRotationShifts returns the users currently on-call for a rotation, and when their
+// shift ends, from the current shifts of its schedule.
+func buildRotationShifts(currentShifts *[]client.ScheduleEntryV2, rotationID string) (types.List, types.String) {
+	userIDs := []attr.Value{}
+	var nextHandoverAt *time.Time
+	for _, shift := range lo.FromPtr(currentShifts) {
+		if lo.FromPtr(shift.RotationId) != rotationID || shift.User == nil {
+			continue
+		}
+
+		userID := types.StringValue(shift.User.Id)
+		if !lo.Contains(userIDs, attr.Value(userID)) {
+			userIDs = append(userIDs, userID)
+		}
+		if nextHandoverAt == nil || shift.EndAt.Before(*nextHandoverAt) {
+			nextHandoverAt = lo.ToPtr(shift.EndAt)
+		}
+	}
+
+	if nextHandoverAt == nil {
+		return types.ListValueMust(types.StringType, userIDs), types.StringNull()
+	}
+
+	return types.ListValueMust(types.StringType, userIDs), types.StringValue(nextHandoverAt.Format(time.RFC3339))
+}
+
+// rotationVersion returns the version at idx of the rotation with the given ID, or nil if
+// there isn't one.
+func (m *IncidentScheduleResourceModel) rotationVersion(rotationID string, idx int) *RotationVersion {
+	if m == nil {
+		return nil
+	}
+
+	for _, rotation := range m.Rotations {
+		if rotation.ID.ValueString() == rotationID && idx < len(rotation.Versions) {
+			return &rotation.Versions[idx]
+		}
+	}
+
+	return nil
 }
@@ -2,11 +2,18 @@ package provider
 
 import (
 	"reflect"
+	"sort"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/incident-io/terraform-provider-incident/internal/apischema"
 	"github.com/incident-io/terraform-provider-incident/internal/client"
 	"github.com/samber/lo"
 )
@@ -86,6 +93,533 @@ func TestAccIncidentScheduleResource(t *testing.T) {
 	})
 }
 
+// TestBuildScheduleCreatePayloadOvernightWorkingInterval checks that overnight working
+// intervals are split into intervals whose times are all ones we'd accept in config.
+func TestBuildScheduleCreatePayloadOvernightWorkingInterval(t *testing.T) {
+	data := &IncidentScheduleResourceModel{
+		Rotations: []Rotation{{
+			ID:   types.StringValue("night-shift"),
+			Name: types.StringValue("Night shift"),
+			Versions: []RotationVersion{{
+				HandoverStartAt: types.StringValue("2024-01-01T22:00:00Z"),
+				WorkingIntervals: []WorkingInterval{{
+					Start: types.StringValue("22:00"),
+					End:   types.StringValue("06:00"),
+					Day:   types.StringValue("monday"),
+				}},
+			}},
+		}},
+	}
+
+	rotations, err := buildScheduleCreatePayload(data, nil, time.Now(), &tfresource.CreateResponse{})
+	if err != nil {
+		t.Fatalf("building payload: %s", err)
+	}
+
+	expected := []client.ScheduleRotationWorkingIntervalV2{
+		{StartTime: "22:00", EndTime: workingIntervalEndOfDay, Weekday: "monday"},
+		{StartTime: "00:00", EndTime: "06:00", Weekday: "tuesday"},
+	}
+	if !reflect.DeepEqual(*rotations[0].WorkingInterval, expected) {
+		t.Fatalf("expected working intervals %v, got %v", expected, *rotations[0].WorkingInterval)
+	}
+	for _, interval := range expected {
+		for _, value := range []string{interval.StartTime, interval.EndTime} {
+			if _, err := parseWorkingIntervalTime(value); err != nil {
+				t.Errorf("working interval time %q isn't valid: %s", value, err)
+			}
+		}
+	}
+}
+
+func TestSplitOvernightWorkingIntervals(t *testing.T) {
+	interval := func(day, start, end string) WorkingInterval {
+		return WorkingInterval{Day: types.StringValue(day), Start: types.StringValue(start), End: types.StringValue(end)}
+	}
+
+	testCases := []struct {
+		name      string
+		intervals []WorkingInterval
+		want      []WorkingInterval
+	}{
+		{
+			name:      "daytime interval is unchanged",
+			intervals: []WorkingInterval{interval("monday", "09:00", "17:00")},
+			want:      []WorkingInterval{interval("monday", "09:00", "17:00")},
+		},
+		{
+			name:      "overnight interval continues on the next day",
+			intervals: []WorkingInterval{interval("thursday", "22:00", "06:00")},
+			want:      []WorkingInterval{interval("thursday", "22:00", "23:59"), interval("friday", "00:00", "06:00")},
+		},
+		{
+			name:      "overnight interval on sunday continues on monday",
+			intervals: []WorkingInterval{interval("sunday", "20:00", "02:30")},
+			want:      []WorkingInterval{interval("sunday", "20:00", "23:59"), interval("monday", "00:00", "02:30")},
+		},
+		{
+			name:      "interval ending at midnight stays on the same day",
+			intervals: []WorkingInterval{interval("friday", "18:00", "00:00")},
+			want:      []WorkingInterval{interval("friday", "18:00", "23:59")},
+		},
+		{
+			name:      "invalid day is left for the API to reject",
+			intervals: []WorkingInterval{interval("someday", "22:00", "06:00")},
+			want:      []WorkingInterval{interval("someday", "22:00", "06:00")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := splitOvernightWorkingIntervals(tc.intervals)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestScheduleWeekdaysMatchAPI(t *testing.T) {
+	got := append([]string{}, scheduleWeekdays...)
+	want := apischema.Enum("ScheduleRotationWorkingIntervalV2ResponseBody", "weekday")
+	sort.Strings(got)
+	sort.Strings(want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the weekdays the API allows, %v, got %v", want, got)
+	}
+}
+
+func TestBuildHandoverSchedule(t *testing.T) {
+	handover := func(every, on, at string) HandoverSchedule {
+		optional := func(value string) types.String {
+			if value == "" {
+				return types.StringNull()
+			}
+			return types.StringValue(value)
+		}
+		return HandoverSchedule{Every: types.StringValue(every), On: optional(on), At: optional(at)}
+	}
+
+	testCases := []struct {
+		name             string
+		handover         HandoverSchedule
+		timezone         string
+		wantStartAt      string
+		wantInterval     int64
+		wantIntervalType string
+		wantError        string
+	}{
+		{
+			name:             "weekly defaults to monday at 09:00",
+			handover:         handover("1w", "", ""),
+			timezone:         "UTC",
+			wantStartAt:      "2024-01-01T09:00:00Z",
+			wantInterval:     1,
+			wantIntervalType: "weekly",
+		},
+		{
+			name:             "weekly on sunday",
+			handover:         handover("2w", "sunday", "17:30"),
+			timezone:         "UTC",
+			wantStartAt:      "2024-01-07T17:30:00Z",
+			wantInterval:     2,
+			wantIntervalType: "weekly",
+		},
+		{
+			name:             "daily anchored in the schedule timezone",
+			handover:         handover("1d", "", "08:00"),
+			timezone:         "America/New_York",
+			wantStartAt:      "2024-01-01T08:00:00-05:00",
+			wantInterval:     1,
+			wantIntervalType: "daily",
+		},
+		{
+			name:             "weekly on wednesday ahead of UTC",
+			handover:         handover("1w", "wednesday", "00:15"),
+			timezone:         "Asia/Tokyo",
+			wantStartAt:      "2024-01-03T00:15:00+09:00",
+			wantInterval:     1,
+			wantIntervalType: "weekly",
+		},
+		{
+			name:             "hourly",
+			handover:         handover("12h", "", "21:00"),
+			timezone:         "UTC",
+			wantStartAt:      "2024-01-01T21:00:00Z",
+			wantInterval:     12,
+			wantIntervalType: "hourly",
+		},
+		{name: "zero interval", handover: handover("0d", "", ""), timezone: "UTC", wantError: "every must be"},
+		{name: "unknown unit", handover: handover("1m", "", ""), timezone: "UTC", wantError: "every must be"},
+		{name: "missing count", handover: handover("d", "", ""), timezone: "UTC", wantError: "every must be"},
+		{name: "fractional count", handover: handover("1.5d", "", ""), timezone: "UTC", wantError: "every must be"},
+		{name: "on with daily", handover: handover("1d", "monday", ""), timezone: "UTC", wantError: "on can only be used with weekly handovers"},
+		{name: "on with hourly", handover: handover("6h", "friday", ""), timezone: "UTC", wantError: "on can only be used with weekly handovers"},
+		{name: "on is not a day", handover: handover("1w", "someday", ""), timezone: "UTC", wantError: "on must be a day of the week"},
+		{name: "at is not a time", handover: handover("1w", "", "9am"), timezone: "UTC", wantError: "at must be a time"},
+		{name: "at is out of range", handover: handover("1w", "", "24:00"), timezone: "UTC", wantError: "at must be a time"},
+		{name: "unknown timezone", handover: handover("1w", "", ""), timezone: "Nowhere/Special", wantError: "unknown time zone"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := buildHandoverSchedule(tc.handover, tc.timezone)
+			if tc.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantError) {
+					t.Fatalf("expected an error containing %q, got %v", tc.wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got.startAt.ValueString() != tc.wantStartAt {
+				t.Errorf("expected handover_start_at %s, got %s", tc.wantStartAt, got.startAt.ValueString())
+			}
+			if len(got.handovers) != 1 {
+				t.Fatalf("expected 1 handover, got %d", len(got.handovers))
+			}
+			if got.handovers[0].Interval.ValueInt64() != tc.wantInterval || got.handovers[0].IntervalType.ValueString() != tc.wantIntervalType {
+				t.Errorf("expected a handover every %d %s, got every %d %s", tc.wantInterval, tc.wantIntervalType,
+					got.handovers[0].Interval.ValueInt64(), got.handovers[0].IntervalType.ValueString())
+			}
+		})
+	}
+}
+
+// testRotationVersions returns a version effective from each of the given times, where
+// an empty string is a version with no effective_from.
+func testRotationVersions(effectiveFroms ...string) []RotationVersion {
+	return lo.Map(effectiveFroms, func(effectiveFrom string, _ int) RotationVersion {
+		if effectiveFrom == "" {
+			return RotationVersion{EffectiveFrom: types.StringNull()}
+		}
+		return RotationVersion{EffectiveFrom: types.StringValue(effectiveFrom)}
+	})
+}
+
+func TestExpiredVersionCount(t *testing.T) {
+	now := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		versions      []RotationVersion
+		retentionDays types.Int64
+		want          int
+	}{
+		{
+			name:          "no retention keeps every version",
+			versions:      testRotationVersions("", "2020-01-01T00:00:00Z"),
+			retentionDays: types.Int64Null(),
+			want:          0,
+		},
+		{
+			name:          "a single version is never expired",
+			versions:      testRotationVersions(""),
+			retentionDays: types.Int64Value(30),
+			want:          0,
+		},
+		{
+			name:          "versions replaced before the cutoff are expired",
+			versions:      testRotationVersions("", "2024-01-01T00:00:00Z", "2024-03-01T00:00:00Z", "2024-05-20T00:00:00Z"),
+			retentionDays: types.Int64Value(30),
+			want:          2,
+		},
+		{
+			name:          "the latest version is kept however old it is",
+			versions:      testRotationVersions("", "2024-01-01T00:00:00Z", "2024-02-01T00:00:00Z"),
+			retentionDays: types.Int64Value(30),
+			want:          2,
+		},
+		{
+			name:          "a version replaced exactly at the cutoff is retained",
+			versions:      testRotationVersions("", "2024-05-02T00:00:00Z"),
+			retentionDays: types.Int64Value(30),
+			want:          0,
+		},
+		{
+			name:          "a version replaced in the future is retained",
+			versions:      testRotationVersions("", "2024-07-01T00:00:00Z"),
+			retentionDays: types.Int64Value(0),
+			want:          0,
+		},
+		{
+			name:          "zero retention expires anything already replaced",
+			versions:      testRotationVersions("", "2024-05-31T23:00:00Z", "2024-07-01T00:00:00Z"),
+			retentionDays: types.Int64Value(0),
+			want:          1,
+		},
+		{
+			name:          "counting stops at an effective_from we can't parse",
+			versions:      testRotationVersions("", "2024-01-01T00:00:00Z", "soon", "2024-02-01T00:00:00Z"),
+			retentionDays: types.Int64Value(30),
+			want:          1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := expiredVersionCount(tc.versions, tc.retentionDays, now); got != tc.want {
+				t.Errorf("expected %d expired versions, got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestExpiredVersions(t *testing.T) {
+	versions := testRotationVersions("", "2024-05-01T12:00:00Z", "2024-05-20T00:00:00Z")
+	model := &IncidentScheduleResourceModel{
+		VersionRetentionDays: types.Int64Value(30),
+		Rotations: []Rotation{{
+			ID:       types.StringValue("primary"),
+			Versions: versions,
+		}},
+	}
+
+	testCases := []struct {
+		name       string
+		model      *IncidentScheduleResourceModel
+		rotationID string
+		remaining  int
+		now        time.Time
+		want       []RotationVersion
+	}{
+		{
+			name:       "no prior model",
+			model:      nil,
+			rotationID: "primary",
+			remaining:  3,
+			now:        time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC),
+			want:       []RotationVersion{},
+		},
+		{
+			name:       "unknown rotation",
+			model:      model,
+			rotationID: "secondary",
+			remaining:  1,
+			now:        time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC),
+			want:       []RotationVersion{},
+		},
+		{
+			name:       "expired versions are restored when the API has the rest",
+			model:      model,
+			rotationID: "primary",
+			remaining:  2,
+			now:        time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC),
+			want:       versions[:1],
+		},
+		{
+			name:       "nothing is restored when the API has a different number of versions",
+			model:      model,
+			rotationID: "primary",
+			remaining:  1,
+			now:        time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC),
+			want:       []RotationVersion{},
+		},
+		{
+			name:       "a version just inside the cutoff hasn't expired",
+			model:      model,
+			rotationID: "primary",
+			remaining:  3,
+			now:        time.Date(2024, time.May, 31, 11, 0, 0, 0, time.UTC),
+			want:       []RotationVersion{},
+		},
+		{
+			name:       "the same version once it has crossed the cutoff",
+			model:      model,
+			rotationID: "primary",
+			remaining:  2,
+			now:        time.Date(2024, time.May, 31, 13, 0, 0, 0, time.UTC),
+			want:       versions[:1],
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.model.expiredVersions(tc.rotationID, tc.remaining, tc.now)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+// TestBuildScheduleUpdatePayloadExpiredVersions checks that the versions we leave out of
+// the payload are exactly those we restore when building the model, given the same time.
+func TestBuildScheduleUpdatePayloadExpiredVersions(t *testing.T) {
+	versions := testRotationVersions("", "2024-05-01T12:00:00Z", "2024-05-20T00:00:00Z")
+	for idx := range versions {
+		versions[idx].HandoverStartAt = types.StringValue("2024-01-01T09:00:00Z")
+	}
+	data := &IncidentScheduleResourceModel{
+		VersionRetentionDays: types.Int64Value(30),
+		Rotations: []Rotation{{
+			ID:       types.StringValue("primary"),
+			Name:     types.StringValue("Primary"),
+			Versions: versions,
+		}},
+	}
+
+	for _, now := range []time.Time{
+		time.Date(2024, time.May, 31, 11, 0, 0, 0, time.UTC),
+		time.Date(2024, time.May, 31, 13, 0, 0, 0, time.UTC),
+		time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		rotations, err := buildScheduleUpdatePayload(data, nil, now, &tfresource.UpdateResponse{})
+		if err != nil {
+			t.Fatalf("building payload: %s", err)
+		}
+
+		expired := data.expiredVersions("primary", len(rotations), now)
+		if len(expired)+len(rotations) != len(versions) {
+			t.Errorf("at %s, sent %d versions and restored %d, but there are %d", now, len(rotations), len(expired), len(versions))
+		}
+	}
+}
+
+func TestHistoricalVersionChanges(t *testing.T) {
+	now := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	// The first two versions have been replaced, the third is in effect now and the last
+	// takes effect in the future.
+	stateVersions := func() []RotationVersion {
+		versions := testRotationVersions("", "2024-03-01T00:00:00Z", "2024-05-01T00:00:00Z", "2024-07-01T00:00:00Z")
+		for idx, user := range []string{"alice", "bob", "carol", "dave"} {
+			versions[idx].HandoverStartAt = types.StringValue("2024-01-01T09:00:00Z")
+			versions[idx].Users = []types.String{types.StringValue(user)}
+		}
+		return versions
+	}
+	model := func(rotationID string, versions []RotationVersion) *IncidentScheduleResourceModel {
+		return &IncidentScheduleResourceModel{
+			Rotations: []Rotation{{ID: types.StringValue(rotationID), Versions: versions}},
+		}
+	}
+	versionPath := func(idx int) path.Path {
+		return path.Root("rotations").AtListIndex(0).AtName("versions").AtListIndex(idx)
+	}
+
+	testCases := []struct {
+		name       string
+		plan       func(versions []RotationVersion) []RotationVersion
+		rotationID string
+		wantPaths  []path.Path
+		wantDetail string
+	}{
+		{
+			name: "unchanged",
+			plan: func(versions []RotationVersion) []RotationVersion { return versions },
+		},
+		{
+			name: "editing a replaced version",
+			plan: func(versions []RotationVersion) []RotationVersion {
+				versions[1].Users = []types.String{types.StringValue("mallory")}
+				return versions
+			},
+			wantPaths:  []path.Path{versionPath(1)},
+			wantDetail: "this plan changes users",
+		},
+		{
+			name: "editing the first version, which has no effective_from",
+			plan: func(versions []RotationVersion) []RotationVersion {
+				versions[0].Handovers = []Handover{{Interval: types.Int64Value(1), IntervalType: types.StringValue("daily")}}
+				return versions
+			},
+			wantPaths:  []path.Path{versionPath(0)},
+			wantDetail: "this plan changes handovers",
+		},
+		{
+			name: "the same handover_start_at in another offset",
+			plan: func(versions []RotationVersion) []RotationVersion {
+				versions[1].HandoverStartAt = types.StringValue("2024-01-01T10:00:00+01:00")
+				return versions
+			},
+		},
+		{
+			name: "handover_start_at that isn't known yet",
+			plan: func(versions []RotationVersion) []RotationVersion {
+				versions[1].HandoverStartAt = types.StringUnknown()
+				return versions
+			},
+		},
+		{
+			name: "editing the version in effect now",
+			plan: func(versions []RotationVersion) []RotationVersion {
+				versions[2].Users = []types.String{types.StringValue("mallory")}
+				return versions
+			},
+		},
+		{
+			name: "editing a future version",
+			plan: func(versions []RotationVersion) []RotationVersion {
+				versions[3].Users = []types.String{types.StringValue("mallory")}
+				return versions
+			},
+		},
+		{
+			name: "removing the oldest version, as version_retention_days does",
+			plan: func(versions []RotationVersion) []RotationVersion { return versions[1:] },
+		},
+		{
+			name: "removing every replaced version",
+			plan: func(versions []RotationVersion) []RotationVersion { return versions[2:] },
+		},
+		{
+			name: "moving the effective_from of the version that replaced one",
+			plan: func(versions []RotationVersion) []RotationVersion {
+				versions[2].EffectiveFrom = types.StringValue("2024-04-15T00:00:00Z")
+				return versions
+			},
+			wantPaths:  []path.Path{versionPath(1)},
+			wantDetail: "the effective_from of the version that replaced it",
+		},
+		{
+			name:       "removing the versions that replaced one",
+			plan:       func(versions []RotationVersion) []RotationVersion { return versions[:2] },
+			wantPaths:  []path.Path{versionPath(1)},
+			wantDetail: "the effective_from of the version that replaced it",
+		},
+		{
+			name: "inserting a version in the past",
+			plan: func(versions []RotationVersion) []RotationVersion {
+				inserted := testRotationVersions("2024-04-01T00:00:00Z")
+				return append(append(versions[:2:2], inserted...), versions[2:]...)
+			},
+			wantPaths:  []path.Path{versionPath(1)},
+			wantDetail: "the effective_from of the version that replaced it",
+		},
+		{
+			name:       "a rotation that isn't in state",
+			rotationID: "secondary",
+			plan: func(versions []RotationVersion) []RotationVersion {
+				versions[1].Users = []types.String{types.StringValue("mallory")}
+				return versions
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rotationID := lo.Ternary(tc.rotationID == "", "primary", tc.rotationID)
+			diags := historicalVersionChanges(model("primary", stateVersions()), model(rotationID, tc.plan(stateVersions())), now)
+
+			if len(diags) != len(tc.wantPaths) {
+				t.Fatalf("expected %d diagnostics, got %v", len(tc.wantPaths), diags)
+			}
+			for idx, d := range diags {
+				withPath, ok := d.(diag.DiagnosticWithPath)
+				if !ok || !withPath.Path().Equal(tc.wantPaths[idx]) {
+					t.Errorf("expected a diagnostic at %s, got %v", tc.wantPaths[idx], d)
+				}
+				if !strings.Contains(d.Detail(), tc.wantDetail) {
+					t.Errorf("expected the diagnostic to mention %q, got %q", tc.wantDetail, d.Detail())
+				}
+			}
+		})
+	}
+}
+
 func incidentScheduleDefault() client.ScheduleV2 {
 	var (
 		effectiveFrom1, _   = time.Parse(time.RFC3339, "2024-04-26T16:00:00Z")
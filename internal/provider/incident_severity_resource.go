@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -22,14 +23,16 @@ var (
 )
 
 type IncidentSeverityResource struct {
-	client *client.ClientWithResponses
+	client   *client.ClientWithResponses
+	apiUsage *apiUsage
 }
 
 type IncidentSeverityResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	Rank        types.Int64  `tfsdk:"rank"`
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	Rank        types.Int64    `tfsdk:"rank"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewIncidentSeverityResource() resource.Resource {
@@ -65,6 +68,9 @@ func (r *IncidentSeverityResource) Schema(ctx context.Context, req resource.Sche
 				Computed:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -84,14 +90,28 @@ func (r *IncidentSeverityResource) Configure(ctx context.Context, req resource.C
 	}
 
 	r.client = client.Client
+	r.apiUsage = client.APIUsage
 }
 
 func (r *IncidentSeverityResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_severity", "Create")
+	defer done()
+
 	var data *IncidentSeverityResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	var rank *int64
 	if !data.Rank.IsUnknown() {
 		rank = lo.ToPtr(data.Rank.ValueInt64())
@@ -102,48 +122,76 @@ func (r *IncidentSeverityResource) Create(ctx context.Context, req resource.Crea
 		Rank:        rank,
 	})
 	if err == nil && result.StatusCode() >= 400 {
-		err = fmt.Errorf(string(result.Body))
+		err = newAPIError(result.StatusCode(), result.Body)
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create incident severity, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Unable to create incident severity", err)
 		return
 	}
 
 	tflog.Trace(ctx, fmt.Sprintf("created an incident severity resource with id=%s", result.JSON201.Severity.Id))
-	data = r.buildModel(result.JSON201.Severity)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON201.Severity)
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentSeverityResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_severity", "Read")
+	defer done()
+
 	var data *IncidentSeverityResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	result, err := r.client.SeveritiesV1ShowWithResponse(ctx, data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read incident severity, got error: %s", err))
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if result.StatusCode() == 404 {
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	result, err := r.client.SeveritiesV1ShowWithResponse(ctx, data.ID.ValueString())
+	if err == nil && result.StatusCode() == 404 {
 		resp.Diagnostics.AddWarning("Not Found", fmt.Sprintf("Unable to read incident severity, got status code: %d", result.StatusCode()))
 		resp.State.RemoveResource(ctx)
 		return
 	}
+	if err == nil && result.StatusCode() >= 400 {
+		err = newAPIError(result.StatusCode(), result.Body)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read incident severity, got error: %s", err))
+		return
+	}
 
-	data = r.buildModel(result.JSON200.Severity)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON200.Severity)
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentSeverityResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_severity", "Update")
+	defer done()
+
 	var data *IncidentSeverityResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	var rank *int64
 	if !data.Rank.IsNull() {
 		rank = lo.ToPtr(data.Rank.ValueInt64())
@@ -154,24 +202,37 @@ func (r *IncidentSeverityResource) Update(ctx context.Context, req resource.Upda
 		Rank:        rank,
 	})
 	if err == nil && result.StatusCode() >= 400 {
-		err = fmt.Errorf(string(result.Body))
+		err = newAPIError(result.StatusCode(), result.Body)
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update incident severity, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Unable to update incident severity", err)
 		return
 	}
 
-	data = r.buildModel(result.JSON200.Severity)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON200.Severity)
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentSeverityResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_severity", "Delete")
+	defer done()
+
 	var data *IncidentSeverityResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	_, err := r.client.SeveritiesV1DeleteWithResponse(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete incident severity, got error: %s", err))
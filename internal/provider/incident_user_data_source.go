@@ -21,7 +21,8 @@ func NewIncidentUserDataSource() datasource.DataSource {
 }
 
 type IncidentUserDataSource struct {
-	client *client.ClientWithResponses
+	client   *client.ClientWithResponses
+	apiUsage *apiUsage
 }
 
 type IncidentUserDataSourceModel struct {
@@ -51,6 +52,7 @@ func (i *IncidentUserDataSource) Configure(ctx context.Context, req datasource.C
 	}
 
 	i.client = client.Client
+	i.apiUsage = client.APIUsage
 }
 
 func (i *IncidentUserDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -58,6 +60,9 @@ func (i *IncidentUserDataSource) Metadata(ctx context.Context, req datasource.Me
 }
 
 func (i *IncidentUserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, done := i.apiUsage.track(ctx, "incident_user", "Read")
+	defer done()
+
 	var data IncidentUserDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 
@@ -68,7 +73,7 @@ func (i *IncidentUserDataSource) Read(ctx context.Context, req datasource.ReadRe
 		}
 		result, err := i.client.UsersV2ShowWithResponse(ctx, data.ID.ValueString())
 		if err == nil && result.StatusCode() >= 400 {
-			err = fmt.Errorf(string(result.Body))
+			err = newAPIError(result.StatusCode(), result.Body)
 		}
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
@@ -80,7 +85,7 @@ func (i *IncidentUserDataSource) Read(ctx context.Context, req datasource.ReadRe
 			Email: data.Email.ValueStringPointer(),
 		})
 		if err == nil && result.StatusCode() >= 400 {
-			err = fmt.Errorf(string(result.Body))
+			err = newAPIError(result.StatusCode(), result.Body)
 		}
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
@@ -99,7 +104,7 @@ func (i *IncidentUserDataSource) Read(ctx context.Context, req datasource.ReadRe
 			SlackUserId: data.SlackUserID.ValueStringPointer(),
 		})
 		if err == nil && result.StatusCode() >= 400 {
-			err = fmt.Errorf(string(result.Body))
+			err = newAPIError(result.StatusCode(), result.Body)
 		}
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
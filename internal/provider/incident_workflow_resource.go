@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -18,13 +21,18 @@ import (
 )
 
 var (
-	_ resource.Resource                = &IncidentWorkflowResource{}
-	_ resource.ResourceWithImportState = &IncidentWorkflowResource{}
+	_ resource.Resource                   = &IncidentWorkflowResource{}
+	_ resource.ResourceWithImportState    = &IncidentWorkflowResource{}
+	_ resource.ResourceWithValidateConfig = &IncidentWorkflowResource{}
+	_ resource.ResourceWithModifyPlan     = &IncidentWorkflowResource{}
 )
 
 type IncidentWorkflowResource struct {
-	client           *client.ClientWithResponses
-	terraformVersion string
+	client                    *client.ClientWithResponses
+	apiUsage                  *apiUsage
+	terraformVersion          string
+	adoptUnmanaged            bool
+	skipManagementAnnotations bool
 }
 
 func NewIncidentWorkflowResource() resource.Resource {
@@ -38,7 +46,9 @@ type IncidentWorkflowResourceModel struct {
 	Trigger                 types.String                  `tfsdk:"trigger"`
 	ConditionGroups         IncidentEngineConditionGroups `tfsdk:"condition_groups"`
 	Steps                   []IncidentWorkflowStep        `tfsdk:"steps"`
+	StepsJSON               types.String                  `tfsdk:"steps_json"`
 	Expressions             IncidentEngineExpressions     `tfsdk:"expressions"`
+	ExpressionsJSON         types.String                  `tfsdk:"expressions_json"`
 	OnceFor                 []types.String                `tfsdk:"once_for"`
 	IncludePrivateIncidents types.Bool                    `tfsdk:"include_private_incidents"`
 	ContinueOnStepError     types.Bool                    `tfsdk:"continue_on_step_error"`
@@ -46,6 +56,7 @@ type IncidentWorkflowResourceModel struct {
 	RunsOnIncidents         types.String                  `tfsdk:"runs_on_incidents"`
 	RunsOnIncidentModes     []types.String                `tfsdk:"runs_on_incident_modes"`
 	State                   types.String                  `tfsdk:"state"`
+	Timeouts                timeouts.Value                `tfsdk:"timeouts"`
 }
 
 type IncidentWorkflowStep struct {
@@ -68,7 +79,9 @@ func (r *IncidentWorkflowResource) Schema(ctx context.Context, req resource.Sche
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `This resource is used to manage Workflows.
 		
-We'd generally recommend building workflows in our [web dashboard](https://app.incident.io/workflows), and using the 'Export' flow to generate your Terraform, as it's easier to see what you've configured. You can also make changes to an existing workflow and copy the resulting Terraform without persisting it. You can learn more in this [Loom](https://www.loom.com/share/b833d7d0fd114d6ba3f24d8c72e5208f?sid=c6d3cc3f-aa93-44ba-b12d-a0a4cbe09448).`,
+We'd generally recommend building workflows in our [web dashboard](https://app.incident.io/workflows), and using the 'Export' flow to generate your Terraform, as it's easier to see what you've configured. You can also make changes to an existing workflow and copy the resulting Terraform without persisting it. You can learn more in this [Loom](https://www.loom.com/share/b833d7d0fd114d6ba3f24d8c72e5208f?sid=c6d3cc3f-aa93-44ba-b12d-a0a4cbe09448).
+
+Steps and expressions can also be provided as JSON, in the same shape the API accepts, using steps_json and expressions_json. These are checked against the API schema when planning, and sent to the API as they are, so you can use parts of a step or expression that this provider doesn't yet support.`,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				MarkdownDescription: apischema.Docstring("WorkflowResponseBody", "id"),
@@ -94,8 +107,9 @@ We'd generally recommend building workflows in our [web dashboard](https://app.i
 			},
 			"condition_groups": conditionGroupsAttribute,
 			"steps": schema.ListNestedAttribute{
-				MarkdownDescription: apischema.Docstring("WorkflowResponseBody", "steps"),
-				Required:            true,
+				MarkdownDescription: apischema.Docstring("WorkflowResponseBody", "steps") + ". Exactly one of steps or steps_json must be set.",
+				Optional:            true,
+				Computed:            true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"for_each": schema.StringAttribute{
@@ -111,7 +125,15 @@ We'd generally recommend building workflows in our [web dashboard](https://app.i
 					},
 				},
 			},
+			"steps_json": schema.StringAttribute{
+				MarkdownDescription: "JSON array of steps, in the shape accepted by the API. Exactly one of steps or steps_json must be set.",
+				Optional:            true,
+			},
 			"expressions": expressionsAttribute,
+			"expressions_json": schema.StringAttribute{
+				MarkdownDescription: "JSON array of expressions, in the shape accepted by the API. Can't be used with expressions.",
+				Optional:            true,
+			},
 			"once_for": schema.ListAttribute{
 				MarkdownDescription: apischema.Docstring("WorkflowResponseBody", "once_for"),
 				Required:            true,
@@ -142,27 +164,51 @@ We'd generally recommend building workflows in our [web dashboard](https://app.i
 			"runs_on_incidents": schema.StringAttribute{
 				MarkdownDescription: apischema.Docstring("WorkflowResponseBody", "runs_on_incidents"),
 				Required:            true,
+				Validators: []validator.String{
+					apischema.OneOf("WorkflowsV2CreateWorkflowRequestBody", "runs_on_incidents"),
+				},
 			},
 			"runs_on_incident_modes": schema.ListAttribute{
 				MarkdownDescription: "Incidents in these modes will be affected by the workflow",
 				Required:            true,
 				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(apischema.OneOf("WorkflowsV2CreateWorkflowRequestBody", "runs_on_incident_modes")),
+				},
 			},
 			"state": schema.StringAttribute{
 				MarkdownDescription: apischema.Docstring("WorkflowResponseBody", "state"),
 				Required:            true,
+				Validators: []validator.String{
+					apischema.OneOf("WorkflowsV2CreateWorkflowRequestBody", "state"),
+				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
 func (r *IncidentWorkflowResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_workflow", "Create")
+	defer done()
+
 	var data *IncidentWorkflowResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	onceFor := []string{}
 	for _, v := range data.OnceFor {
 		onceFor = append(onceFor, v.ValueString())
@@ -186,9 +232,7 @@ func (r *IncidentWorkflowResource) Create(ctx context.Context, req resource.Crea
 		IncludePrivateIncidents: data.IncludePrivateIncidents.ValueBool(),
 		ContinueOnStepError:     data.ContinueOnStepError.ValueBool(),
 		State:                   lo.ToPtr(client.CreateWorkflowRequestBodyState(data.State.ValueString())),
-		Annotations: &map[string]string{
-			"incident.io/terraform/version": r.terraformVersion,
-		},
+		Annotations:             managementAnnotations(r.terraformVersion, r.skipManagementAnnotations),
 	}
 
 	if data.Delay != nil {
@@ -198,21 +242,36 @@ func (r *IncidentWorkflowResource) Create(ctx context.Context, req resource.Crea
 		}
 	}
 
-	result, err := r.client.WorkflowsV2CreateWorkflowWithResponse(ctx, payload)
+	var result *client.WorkflowsV2CreateWorkflowResponse
+	body, err := workflowRequestBody(payload, data.StepsJSON, data.ExpressionsJSON)
+	if err == nil {
+		result, err = r.client.WorkflowsV2CreateWorkflowWithBodyWithResponse(ctx, "application/json", body)
+	}
 	if err == nil && result.StatusCode() >= 400 {
-		err = fmt.Errorf(string(result.Body))
+		err = newAPIError(result.StatusCode(), result.Body)
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create workflow, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Unable to create workflow", err)
 		return
 	}
 
 	tflog.Trace(ctx, fmt.Sprintf("created a workflow resource with id=%s", result.JSON201.Workflow.Id))
-	data = r.buildModel(result.JSON201.Workflow)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON201.Workflow)
+	model.StepsJSON = data.StepsJSON
+	model.ExpressionsJSON = data.ExpressionsJSON
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+
+	// We claim the workflow once it's in state, so if this fails we won't lose track of it.
+	if !r.skipManagementAnnotations {
+		claimResource(ctx, r.client, result.JSON201.Workflow.Id, &resp.Diagnostics, client.ManagedResourceV2ResourceTypeWorkflow, r.terraformVersion)
+	}
 }
 
 func (r *IncidentWorkflowResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_workflow", "Update")
+	defer done()
+
 	var state *IncidentWorkflowResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -225,6 +284,15 @@ func (r *IncidentWorkflowResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	onceFor := []string{}
 	for _, v := range data.OnceFor {
 		onceFor = append(onceFor, v.ValueString())
@@ -247,9 +315,7 @@ func (r *IncidentWorkflowResource) Update(ctx context.Context, req resource.Upda
 		IncludePrivateIncidents: data.IncludePrivateIncidents.ValueBool(),
 		ContinueOnStepError:     data.ContinueOnStepError.ValueBool(),
 		State:                   lo.ToPtr(client.UpdateWorkflowRequestBodyState(data.State.ValueString())),
-		Annotations: &map[string]string{
-			"incident.io/terraform/version": r.terraformVersion,
-		},
+		Annotations:             managementAnnotations(r.terraformVersion, r.skipManagementAnnotations),
 	}
 
 	if data.Delay != nil {
@@ -259,46 +325,85 @@ func (r *IncidentWorkflowResource) Update(ctx context.Context, req resource.Upda
 		}
 	}
 
-	result, err := r.client.WorkflowsV2UpdateWorkflowWithResponse(ctx, state.ID.ValueString(), payload)
+	var result *client.WorkflowsV2UpdateWorkflowResponse
+	body, err := workflowRequestBody(payload, data.StepsJSON, data.ExpressionsJSON)
+	if err == nil {
+		result, err = r.client.WorkflowsV2UpdateWorkflowWithBodyWithResponse(ctx, state.ID.ValueString(), "application/json", body)
+	}
 	if err == nil && result.StatusCode() >= 400 {
-		err = fmt.Errorf(string(result.Body))
+		err = newAPIError(result.StatusCode(), result.Body)
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update workflow, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Unable to update workflow", err)
 		return
 	}
 
-	data = r.buildModel(result.JSON200.Workflow)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON200.Workflow)
+	model.StepsJSON = data.StepsJSON
+	model.ExpressionsJSON = data.ExpressionsJSON
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentWorkflowResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_workflow", "Read")
+	defer done()
+
 	var data *IncidentWorkflowResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	result, err := r.client.WorkflowsV2ShowWorkflowWithResponse(ctx, data.ID.ValueString())
+	if err == nil && result.StatusCode() == 404 {
+		resp.Diagnostics.AddWarning("Not Found", fmt.Sprintf("Unable to read workflow, got status code: %d", result.StatusCode()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err == nil && result.StatusCode() >= 400 {
-		err = fmt.Errorf(string(result.Body))
+		err = newAPIError(result.StatusCode(), result.Body)
 	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workflow, got error: %s", err))
 		return
 	}
 
-	data = r.buildModel(result.JSON200.Workflow)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	model := r.buildModel(result.JSON200.Workflow)
+	model.StepsJSON = data.StepsJSON
+	model.ExpressionsJSON = data.ExpressionsJSON
+	model.Timeouts = data.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (r *IncidentWorkflowResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_workflow", "Delete")
+	defer done()
+
 	var data *IncidentWorkflowResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	_, err := r.client.WorkflowsV2DestroyWorkflowWithResponse(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete workflow, got error: %s", err))
@@ -306,8 +411,114 @@ func (r *IncidentWorkflowResource) Delete(ctx context.Context, req resource.Dele
 	}
 }
 
+func (r *IncidentWorkflowResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var (
+		steps           types.List
+		stepsJSON       types.String
+		expressions     types.Set
+		expressionsJSON types.String
+	)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("steps"), &steps)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("steps_json"), &stepsJSON)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("expressions"), &expressions)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("expressions_json"), &expressionsJSON)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if steps.IsNull() == stepsJSON.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("steps_json"), "Invalid workflow steps",
+			"Exactly one of steps or steps_json must be set.")
+	}
+	if !stepsJSON.IsNull() && !stepsJSON.IsUnknown() {
+		if _, err := parseWorkflowStepsJSON(stepsJSON.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("steps_json"), "Invalid workflow steps JSON", err.Error())
+		}
+	}
+
+	if !expressions.IsNull() && !expressionsJSON.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("expressions_json"), "Invalid workflow expressions",
+			"Only one of expressions or expressions_json can be set.")
+	}
+	if !expressionsJSON.IsNull() && !expressionsJSON.IsUnknown() {
+		if _, err := parseWorkflowExpressionsJSON(expressionsJSON.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("expressions_json"), "Invalid workflow expressions JSON", err.Error())
+		}
+	}
+}
+
+// ModifyPlan expands steps_json and expressions_json into the steps and expressions
+// attributes, so they are planned as if they had been configured directly.
+func (r *IncidentWorkflowResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx, done := r.apiUsage.track(ctx, "incident_workflow", "ModifyPlan")
+	defer done()
+
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var stepsJSON types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("steps_json"), &stepsJSON)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !stepsJSON.IsNull() && !stepsJSON.IsUnknown() {
+		steps, err := parseWorkflowStepsJSON(stepsJSON.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("steps_json"), "Invalid workflow steps JSON", err.Error())
+			return
+		}
+
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("steps"), steps)...)
+	}
+
+	var (
+		expressions     types.Set
+		expressionsJSON types.String
+	)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("expressions"), &expressions)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("expressions_json"), &expressionsJSON)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	switch {
+	case !expressionsJSON.IsNull() && !expressionsJSON.IsUnknown():
+		parsed, err := parseWorkflowExpressionsJSON(expressionsJSON.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("expressions_json"), "Invalid workflow expressions JSON", err.Error())
+			return
+		}
+
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expressions"), parsed)...)
+	case expressionsJSON.IsNull() && expressions.IsNull():
+		// Expressions are optional, and a workflow without any has none.
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expressions"), IncidentEngineExpressions{})...)
+	}
+}
+
 func (r *IncidentWorkflowResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	claimResource(ctx, r.client, req, resp, client.ManagedResourceV2ResourceTypeWorkflow, r.terraformVersion)
+	ctx, done := r.apiUsage.track(ctx, "incident_workflow", "ImportState")
+	defer done()
+
+	if !r.adoptUnmanaged {
+		result, err := r.client.WorkflowsV2ShowWorkflowWithResponse(ctx, req.ID)
+		if err == nil && result.StatusCode() >= 400 {
+			err = newAPIError(result.StatusCode(), result.Body)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import workflow, got error: %s", err))
+			return
+		}
+
+		refuseUnmanagedImport(&resp.Diagnostics, client.ManagedResourceV2ResourceTypeWorkflow, req.ID, result.JSON200.ManagementMeta.ManagedBy == client.Dashboard)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !r.skipManagementAnnotations {
+		claimResource(ctx, r.client, req.ID, &resp.Diagnostics, client.ManagedResourceV2ResourceTypeWorkflow, r.terraformVersion)
+	}
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
@@ -327,7 +538,10 @@ func (r *IncidentWorkflowResource) Configure(ctx context.Context, req resource.C
 	}
 
 	r.client = client.Client
+	r.apiUsage = client.APIUsage
 	r.terraformVersion = client.TerraformVersion
+	r.adoptUnmanaged = client.AdoptUnmanaged
+	r.skipManagementAnnotations = client.SkipManagementAnnotations
 }
 
 // buildModel converts from the response type to the terraform model/schema type.
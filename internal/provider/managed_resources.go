@@ -4,25 +4,50 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/incident-io/terraform-provider-incident/internal/client"
 )
 
-func claimResource(ctx context.Context, apiClient *client.ClientWithResponses, req resource.ImportStateRequest, resp *resource.ImportStateResponse, resourceType client.ManagedResourceV2ResourceType, terraformVersion string) {
+// managementAnnotations returns the annotations that record which version of terraform
+// created or last updated an object, or nil if the provider is configured to skip them.
+func managementAnnotations(terraformVersion string, skip bool) *map[string]string {
+	if skip {
+		return nil
+	}
+
+	return &map[string]string{
+		"incident.io/terraform/version": terraformVersion,
+	}
+}
+
+// claimResource marks the resource with the given ID as managed by terraform, so it can't
+// be edited in the dashboard once created or imported.
+func claimResource(ctx context.Context, apiClient *client.ClientWithResponses, resourceID string, diags *diag.Diagnostics, resourceType client.ManagedResourceV2ResourceType, terraformVersion string) {
 	payload := client.CreateManagedResourceRequestBody{
 		Annotations: map[string]string{
 			"incident.io/terraform/version": terraformVersion,
 		},
 		ResourceType: client.CreateManagedResourceRequestBodyResourceType(resourceType),
-		ResourceId:   req.ID,
+		ResourceId:   resourceID,
 	}
 
 	result, err := apiClient.ManagedResourcesV2CreateManagedResourceWithResponse(ctx, payload)
 	if err == nil && result.StatusCode() >= 400 {
-		err = fmt.Errorf(string(result.Body))
+		err = newAPIError(result.StatusCode(), result.Body)
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create managed resource, got error: %s", err))
+		diags.AddError("Client Error", fmt.Sprintf("Unable to create managed resource, got error: %s", err))
 		return
 	}
 }
+
+// refuseUnmanagedImport reports an error when importing a resource that's managed in the
+// dashboard, for providers that have been configured not to adopt such resources.
+func refuseUnmanagedImport(diags *diag.Diagnostics, resourceType client.ManagedResourceV2ResourceType, resourceID string, managedInDashboard bool) {
+	if !managedInDashboard {
+		return
+	}
+
+	diags.AddError("Unmanaged Resource",
+		fmt.Sprintf("The %s %s is managed in the incident.io dashboard, and importing it would prevent it being edited there. Set adopt_unmanaged in the provider configuration to import it anyway.", resourceType, resourceID))
+}
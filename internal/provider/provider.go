@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"time"
 
 	_ "embed"
 
@@ -21,18 +22,46 @@ import (
 
 var _ provider.Provider = &IncidentProvider{}
 
+// defaultTimeout is how long we allow each operation on a resource to take, unless its
+// timeouts block says otherwise.
+const defaultTimeout = 20 * time.Minute
+
 type IncidentProvider struct {
 	version string
 }
 
 type IncidentProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
-	APIKey   types.String `tfsdk:"api_key"`
+	Endpoint                     types.String `tfsdk:"endpoint"`
+	APIKey                       types.String `tfsdk:"api_key"`
+	ProtectDestructiveOperations types.Bool   `tfsdk:"protect_destructive_operations"`
+	AdoptUnmanaged               types.Bool   `tfsdk:"adopt_unmanaged"`
+	SkipManagementAnnotations    types.Bool   `tfsdk:"skip_management_annotations"`
+	ValidateReferences           types.Bool   `tfsdk:"validate_references"`
+	ReadOnly                     types.Bool   `tfsdk:"read_only"`
 }
 
 type IncidentProviderData struct {
 	Client           *client.ClientWithResponses
 	TerraformVersion string
+
+	// ProtectDestructiveOperations is set when resources should refuse to delete
+	// schedules or wipe catalog entries, unless they set allow_destructive_operations.
+	ProtectDestructiveOperations bool
+
+	// AdoptUnmanaged is set when resources may import objects that are managed in the
+	// dashboard, taking over management of them from there.
+	AdoptUnmanaged bool
+
+	// SkipManagementAnnotations is set when resources shouldn't annotate the objects they
+	// create or update with the version of terraform, nor claim them as managed.
+	SkipManagementAnnotations bool
+
+	// ValidateReferences is set when resources should check, at plan time, that the
+	// objects they refer to by ID exist.
+	ValidateReferences bool
+
+	// APIUsage counts the requests each resource makes to the API.
+	APIUsage *apiUsage
 }
 
 func New(version string) func() provider.Provider {
@@ -71,6 +100,26 @@ Registry](https://registry.terraform.io/providers/incident-io/incident/latest).
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"protect_destructive_operations": schema.BoolAttribute{
+				MarkdownDescription: "If true, destroying an `incident_schedule`, or deleting every entry managed by an `incident_catalog_entries` or `incident_catalog_entries_set`, fails unless that resource sets `allow_destructive_operations`. A safety net for workspaces shared by many teams.",
+				Optional:            true,
+			},
+			"adopt_unmanaged": schema.BoolAttribute{
+				MarkdownDescription: "If false, importing an `incident_schedule` or `incident_workflow` that's managed in the incident.io dashboard fails, rather than taking over management of it. Defaults to true.",
+				Optional:            true,
+			},
+			"skip_management_annotations": schema.BoolAttribute{
+				MarkdownDescription: "If true, catalog types, schedules and workflows aren't annotated with the version of terraform that manages them, and schedules and workflows aren't claimed as managed by terraform, saving the writes this makes. Schedules created this way can't be imported when `adopt_unmanaged` is false.",
+				Optional:            true,
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "If true, the provider only reads from incident.io, and any create, update or delete fails without making changes. Plans and refreshes work as normal, making this suitable for scheduled drift detection. Importing a schedule or workflow also fails, as this claims it as managed by terraform, unless `skip_management_annotations` is set.",
+				Optional:            true,
+			},
+			"validate_references": schema.BoolAttribute{
+				MarkdownDescription: "If true, plans check that the catalog types, custom fields and users that resources refer to by ID exist, reporting any that don't against the attribute that refers to them. This makes an API request for each reference on every plan.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -104,9 +153,18 @@ func (p *IncidentProvider) Configure(ctx context.Context, req provider.Configure
 		panic(bearerTokenProviderErr)
 	}
 
+	var transport http.RoundTripper = &apiUsageTransport{
+		transport: &loghttp.Transport{
+			Transport: cleanhttp.DefaultTransport(),
+		},
+	}
+	if data.ReadOnly.ValueBool() {
+		transport = &readOnlyTransport{transport: transport}
+	}
+
 	base := cleanhttp.DefaultClient()
-	base.Transport = &loghttp.Transport{
-		Transport: cleanhttp.DefaultTransport(),
+	base.Transport = &rateLimitThrottleTransport{
+		transport: transport,
 	}
 
 	client, err := client.NewClientWithResponses(
@@ -123,19 +181,36 @@ func (p *IncidentProvider) Configure(ctx context.Context, req provider.Configure
 		panic(err)
 	}
 
+	adoptUnmanaged := true
+	if !data.AdoptUnmanaged.IsNull() && !data.AdoptUnmanaged.IsUnknown() {
+		adoptUnmanaged = data.AdoptUnmanaged.ValueBool()
+	}
+
+	usage := newAPIUsage()
 	resp.DataSourceData = &IncidentProviderData{
-		Client:           client,
-		TerraformVersion: req.TerraformVersion,
+		Client:                       client,
+		TerraformVersion:             req.TerraformVersion,
+		ProtectDestructiveOperations: data.ProtectDestructiveOperations.ValueBool(),
+		AdoptUnmanaged:               adoptUnmanaged,
+		SkipManagementAnnotations:    data.SkipManagementAnnotations.ValueBool(),
+		ValidateReferences:           data.ValidateReferences.ValueBool(),
+		APIUsage:                     usage,
 	}
 	resp.ResourceData = &IncidentProviderData{
-		Client:           client,
-		TerraformVersion: req.TerraformVersion,
+		Client:                       client,
+		TerraformVersion:             req.TerraformVersion,
+		ProtectDestructiveOperations: data.ProtectDestructiveOperations.ValueBool(),
+		AdoptUnmanaged:               adoptUnmanaged,
+		SkipManagementAnnotations:    data.SkipManagementAnnotations.ValueBool(),
+		ValidateReferences:           data.ValidateReferences.ValueBool(),
+		APIUsage:                     usage,
 	}
 }
 
 func (p *IncidentProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewIncidentCatalogEntriesResource,
+		NewIncidentCatalogEntriesSetResource,
 		NewIncidentCatalogEntryResource,
 		NewIncidentCatalogTypeAttributesResource,
 		NewIncidentCatalogTypeResource,
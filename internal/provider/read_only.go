@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// errReadOnly is returned for any request that read_only refuses. It's never worth
+// retrying, as the request would be refused again.
+var errReadOnly = errors.New("the provider is configured to be read_only")
+
+// readOnlyTransport refuses any request that could change configuration, for providers
+// that set read_only. Every read the provider makes is a GET, so that's all we allow,
+// which means plans and refreshes work as normal while creates, updates and deletes fail
+// on their first request.
+type readOnlyTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return nil, fmt.Errorf("%w, so won't make a %s request that would change configuration",
+			errReadOnly, req.Method)
+	}
+
+	return t.transport.RoundTrip(req)
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/incident-io/terraform-provider-incident/internal/client"
+)
+
+// validateReference checks, for providers that set validate_references, that the object
+// with the given ID exists, reporting it against the attribute that refers to it if not.
+// IDs that aren't yet known are skipped, as they'll be checked when we plan again during
+// apply.
+func validateReference(ctx context.Context, diags *diag.Diagnostics, attributePath path.Path, kind string, id types.String, exists func(ctx context.Context, id string) (bool, error)) {
+	if id.IsNull() || id.IsUnknown() {
+		return
+	}
+
+	found, err := exists(ctx, id.ValueString())
+	if err != nil {
+		diags.AddAttributeError(attributePath, "Client Error", fmt.Sprintf("Unable to look up %s %s, got error: %s", kind, id.ValueString(), err))
+		return
+	}
+	if !found {
+		diags.AddAttributeError(attributePath, "Reference not found", fmt.Sprintf("No %s found with ID %q.", kind, id.ValueString()))
+	}
+}
+
+// catalogTypeExists returns a function that checks whether there is a catalog type with
+// the given ID, for use with validateReference.
+func catalogTypeExists(apiClient *client.ClientWithResponses) func(ctx context.Context, id string) (bool, error) {
+	return func(ctx context.Context, id string) (bool, error) {
+		result, err := apiClient.CatalogV2ShowTypeWithResponse(ctx, id)
+		if err == nil && result.StatusCode() == 404 {
+			return false, nil
+		}
+		if err == nil && result.StatusCode() >= 400 {
+			err = newAPIError(result.StatusCode(), result.Body)
+		}
+		if err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+}
+
+// customFieldExists returns a function that checks whether there is a custom field with
+// the given ID, for use with validateReference.
+func customFieldExists(apiClient *client.ClientWithResponses) func(ctx context.Context, id string) (bool, error) {
+	return func(ctx context.Context, id string) (bool, error) {
+		result, err := apiClient.CustomFieldsV2ShowWithResponse(ctx, id)
+		if err == nil && result.StatusCode() == 404 {
+			return false, nil
+		}
+		if err == nil && result.StatusCode() >= 400 {
+			err = newAPIError(result.StatusCode(), result.Body)
+		}
+		if err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+}
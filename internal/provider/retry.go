@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 30 * time.Second
+)
+
+// statusCoder is implemented by every response in our generated client.
+type statusCoder interface {
+	StatusCode() int
+}
+
+// withRetries calls do until it succeeds, fails with an error that isn't transient, or
+// we've retried maxRetries times, backing off exponentially between attempts.
+//
+// Transport errors such as timeouts, rate limits and server errors are considered
+// transient, other than requests refused because the provider is read_only. Any other
+// response is returned as-is for the caller to handle.
+func withRetries[T statusCoder](ctx context.Context, maxRetries int64, do func() (T, error)) (T, error) {
+	for attempt := int64(0); ; attempt++ {
+		result, err := do()
+		if !isTransient(result, err) || attempt >= maxRetries {
+			return result, err
+		}
+
+		apiUsageTrackerFromContext(ctx).recordRetry()
+
+		delay := retryBaseDelay << attempt
+		if delay > retryMaxDelay || delay <= 0 {
+			delay = retryMaxDelay
+		}
+
+		if err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("request failed with transient error, retrying in %s: %s", delay, err))
+		} else {
+			tflog.Debug(ctx, fmt.Sprintf("request failed with status %d, retrying in %s", result.StatusCode(), delay))
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, err
+		case <-time.After(delay):
+		}
+	}
+}
+
+func isTransient(result statusCoder, err error) bool {
+	if errors.Is(err, errReadOnly) {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+
+	return result.StatusCode() == http.StatusTooManyRequests || result.StatusCode() >= 500
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// rateLimitThrottle limits how many requests a pool of workers makes at once, adjusting
+// that limit from the rate-limit headers on each response. When we have plenty of our
+// allowance left we work up to the configured concurrency, but as it runs low we back off
+// so a long sync doesn't end in a wall of 429s.
+type rateLimitThrottle struct {
+	mu     sync.Mutex
+	max    int
+	limit  int
+	active int
+	wake   chan struct{}
+}
+
+const (
+	// rateLimitLowRemaining is the fraction of our allowance below which we start to slow
+	// down, and rateLimitCriticalRemaining that below which we slow down sharply.
+	rateLimitLowRemaining      = 0.25
+	rateLimitCriticalRemaining = 0.1
+)
+
+func newRateLimitThrottle(concurrency int64) *rateLimitThrottle {
+	return &rateLimitThrottle{
+		max:   int(concurrency),
+		limit: int(concurrency),
+		wake:  make(chan struct{}),
+	}
+}
+
+// acquire blocks until another request can be made, or ctx is done.
+func (t *rateLimitThrottle) acquire(ctx context.Context) error {
+	for {
+		t.mu.Lock()
+		if t.active < t.limit {
+			t.active++
+			t.mu.Unlock()
+			return nil
+		}
+		wake := t.wake
+		t.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wake:
+		}
+	}
+}
+
+// release marks a request as finished, adjusting the limit from its response, which may
+// be nil if the request failed before we got one.
+func (t *rateLimitThrottle) release(resp *http.Response) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.active--
+	if resp != nil {
+		t.observe(resp)
+	}
+
+	// Wake anyone waiting, who'll check again whether they're now within the limit.
+	close(t.wake)
+	t.wake = make(chan struct{})
+}
+
+func (t *rateLimitThrottle) observe(resp *http.Response) {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		t.limit = t.limit / 2
+	} else if limit, remaining, ok := rateLimitHeaders(resp.Header); ok {
+		switch fraction := float64(remaining) / float64(limit); {
+		case fraction < rateLimitCriticalRemaining:
+			t.limit = t.limit / 2
+		case fraction < rateLimitLowRemaining:
+			t.limit--
+		default:
+			t.limit++
+		}
+	} else {
+		t.limit++
+	}
+
+	if t.limit < 1 {
+		t.limit = 1
+	}
+	if t.limit > t.max {
+		t.limit = t.max
+	}
+}
+
+type rateLimitThrottleKey struct{}
+
+// withRateLimitThrottle returns a context whose requests are made through the throttle.
+func withRateLimitThrottle(ctx context.Context, throttle *rateLimitThrottle) context.Context {
+	return context.WithValue(ctx, rateLimitThrottleKey{}, throttle)
+}
+
+// rateLimitThrottleTransport makes requests through the throttle in their context, if
+// there is one.
+type rateLimitThrottleTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *rateLimitThrottleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	throttle, ok := req.Context().Value(rateLimitThrottleKey{}).(*rateLimitThrottle)
+	if !ok {
+		return t.transport.RoundTrip(req)
+	}
+
+	if err := throttle.acquire(req.Context()); err != nil {
+		return nil, err
+	}
+	resp, err := t.transport.RoundTrip(req)
+	throttle.release(resp)
+
+	return resp, err
+}
+
+// rateLimitHeaders returns the size of our rate-limit allowance and how much of it we
+// have remaining, if the response told us.
+func rateLimitHeaders(header http.Header) (limit, remaining int, ok bool) {
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		limit, limitErr := strconv.Atoi(header.Get(prefix + "Limit"))
+		remaining, remainingErr := strconv.Atoi(header.Get(prefix + "Remaining"))
+		if limitErr == nil && remainingErr == nil && limit > 0 {
+			return limit, remaining, true
+		}
+	}
+
+	return 0, 0, false
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	// Embed the IANA database so timezones validate the same way regardless of whether the
+	// machine running terraform has zoneinfo installed.
+	_ "time/tzdata"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// timezoneAliases maps the most common backward-compatible names in the IANA database to
+// the canonical zone they link to. These still load, but the API reports schedules using
+// the canonical name.
+var timezoneAliases = map[string]string{
+	"GB":                   "Europe/London",
+	"GB-Eire":              "Europe/London",
+	"Eire":                 "Europe/Dublin",
+	"Europe/Kiev":          "Europe/Kyiv",
+	"Asia/Calcutta":        "Asia/Kolkata",
+	"Asia/Saigon":          "Asia/Ho_Chi_Minh",
+	"Asia/Katmandu":        "Asia/Kathmandu",
+	"Asia/Rangoon":         "Asia/Yangon",
+	"Australia/ACT":        "Australia/Sydney",
+	"Australia/NSW":        "Australia/Sydney",
+	"Australia/Canberra":   "Australia/Sydney",
+	"Australia/Victoria":   "Australia/Melbourne",
+	"Australia/West":       "Australia/Perth",
+	"Australia/North":      "Australia/Darwin",
+	"Australia/South":      "Australia/Adelaide",
+	"Australia/Queensland": "Australia/Brisbane",
+	"Brazil/East":          "America/Sao_Paulo",
+	"Canada/Atlantic":      "America/Halifax",
+	"Canada/Central":       "America/Winnipeg",
+	"Canada/Eastern":       "America/Toronto",
+	"Canada/Mountain":      "America/Edmonton",
+	"Canada/Pacific":       "America/Vancouver",
+	"Hongkong":             "Asia/Hong_Kong",
+	"Israel":               "Asia/Jerusalem",
+	"Japan":                "Asia/Tokyo",
+	"NZ":                   "Pacific/Auckland",
+	"PRC":                  "Asia/Shanghai",
+	"ROK":                  "Asia/Seoul",
+	"Singapore":            "Asia/Singapore",
+	"US/Alaska":            "America/Anchorage",
+	"US/Arizona":           "America/Phoenix",
+	"US/Central":           "America/Chicago",
+	"US/Eastern":           "America/New_York",
+	"US/Hawaii":            "Pacific/Honolulu",
+	"US/Mountain":          "America/Denver",
+	"US/Pacific":           "America/Los_Angeles",
+	"Etc/UCT":              "Etc/UTC",
+	"Etc/Universal":        "Etc/UTC",
+	"Etc/Zulu":             "Etc/UTC",
+	"UCT":                  "Etc/UTC",
+	"Universal":            "Etc/UTC",
+	"Zulu":                 "Etc/UTC",
+}
+
+// canonicalTimezone checks that name is in the IANA timezone database, returning the
+// canonical name for it if it's a known alias.
+func canonicalTimezone(name string) (string, error) {
+	// LoadLocation treats the empty string and "Local" as special cases, neither of which
+	// are something we can send to the API.
+	if name == "" || name == "Local" {
+		return "", fmt.Errorf("%q is not a valid IANA timezone", name)
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return "", fmt.Errorf("%q is not a valid IANA timezone, such as Europe/London or America/New_York", name)
+	}
+
+	if canonical, ok := timezoneAliases[name]; ok {
+		return canonical, nil
+	}
+
+	return name, nil
+}
+
+// timezonesEquivalent returns true if both names refer to the same IANA timezone.
+func timezonesEquivalent(left, right string) bool {
+	if left == right {
+		return true
+	}
+
+	canonicalLeft, leftErr := canonicalTimezone(left)
+	canonicalRight, rightErr := canonicalTimezone(right)
+
+	return leftErr == nil && rightErr == nil && canonicalLeft == canonicalRight
+}
+
+var _ validator.String = timezoneValidator{}
+
+// timezoneValidator checks a string attribute is a timezone from the IANA database.
+type timezoneValidator struct{}
+
+func (v timezoneValidator) Description(ctx context.Context) string {
+	return "value must be a timezone from the IANA database, such as Europe/London"
+}
+
+func (v timezoneValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timezoneValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := canonicalTimezone(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid timezone", err.Error())
+	}
+}
@@ -0,0 +1,246 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/incident-io/terraform-provider-incident/internal/apischema"
+	"github.com/incident-io/terraform-provider-incident/internal/client"
+	"github.com/pkg/errors"
+)
+
+// parseWorkflowStepsJSON decodes the content of steps_json, checking each step against the
+// API schema, into the same model we'd have received had the steps been provided using the
+// steps attribute.
+func parseWorkflowStepsJSON(content string) ([]IncidentWorkflowStep, error) {
+	var steps []client.StepConfigPayload
+	if err := decodeWorkflowJSON(content, "StepConfigPayloadRequestBody", &steps); err != nil {
+		return nil, errors.Wrap(err, "decoding steps")
+	}
+
+	return fromPayloadSteps(steps), nil
+}
+
+// parseWorkflowExpressionsJSON decodes the content of expressions_json in the same way as
+// parseWorkflowStepsJSON does for steps.
+func parseWorkflowExpressionsJSON(content string) (IncidentEngineExpressions, error) {
+	var expressions []client.ExpressionPayloadV2
+	if err := decodeWorkflowJSON(content, "ExpressionPayloadV2RequestBody", &expressions); err != nil {
+		return nil, errors.Wrap(err, "decoding expressions")
+	}
+
+	return fromPayloadExpressions(expressions), nil
+}
+
+// decodeWorkflowJSON decodes a JSON array into result, after checking each of its elements
+// against the named definition from the API schema.
+func decodeWorkflowJSON(content string, definitionName string, result any) error {
+	decoder := json.NewDecoder(bytes.NewBufferString(content))
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return fmt.Errorf("unexpected content after the array")
+	}
+
+	elements, ok := document.([]any)
+	if !ok {
+		return fmt.Errorf("must be a JSON array")
+	}
+	for idx, element := range elements {
+		if err := apischema.Validate(definitionName, element); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("element %d", idx))
+		}
+	}
+
+	return json.Unmarshal([]byte(content), result)
+}
+
+// workflowRequestBody encodes the payload for creating or updating a workflow. If the
+// steps or expressions were provided as JSON, we send that JSON as it was given, so any
+// parts of it that we don't model reach the API untouched.
+func workflowRequestBody(payload any, stepsJSON, expressionsJSON types.String) (io.Reader, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &body); err != nil {
+		return nil, err
+	}
+	if !stepsJSON.IsNull() {
+		body["steps"] = json.RawMessage(stepsJSON.ValueString())
+	}
+	if !expressionsJSON.IsNull() {
+		body["expressions"] = json.RawMessage(expressionsJSON.ValueString())
+	}
+
+	encoded, err = json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(encoded), nil
+}
+
+// The functions below convert from the payload types to the terraform model, matching the
+// conversion IncidentWorkflowResource makes from the response types, so the steps and
+// expressions we plan from JSON are the same as those we'll read back from the API.
+
+func fromPayloadSteps(steps []client.StepConfigPayload) []IncidentWorkflowStep {
+	out := []IncidentWorkflowStep{}
+
+	for _, s := range steps {
+		out = append(out, IncidentWorkflowStep{
+			ForEach:       types.StringPointerValue(s.ForEach),
+			ID:            types.StringValue(s.Id),
+			Name:          types.StringValue(s.Name),
+			ParamBindings: fromPayloadParamBindings(s.ParamBindings),
+		})
+	}
+
+	return out
+}
+
+func fromPayloadConditionGroups(groups []client.ConditionGroupPayloadV2) IncidentEngineConditionGroups {
+	var out IncidentEngineConditionGroups
+
+	for _, g := range groups {
+		out = append(out, IncidentEngineConditionGroup{
+			Conditions: fromPayloadConditions(g.Conditions),
+		})
+	}
+
+	return out
+}
+
+func fromPayloadConditions(conditions []client.ConditionPayloadV2) []IncidentEngineCondition {
+	out := []IncidentEngineCondition{}
+
+	for _, c := range conditions {
+		out = append(out, IncidentEngineCondition{
+			Subject:       types.StringValue(c.Subject),
+			Operation:     types.StringValue(c.Operation),
+			ParamBindings: fromPayloadParamBindings(c.ParamBindings),
+		})
+	}
+
+	return out
+}
+
+func fromPayloadParamBindings(pbs []client.EngineParamBindingPayloadV2) []IncidentEngineParamBinding {
+	out := []IncidentEngineParamBinding{}
+
+	for _, pb := range pbs {
+		out = append(out, fromPayloadParamBinding(pb))
+	}
+
+	return out
+}
+
+func fromPayloadParamBinding(pb client.EngineParamBindingPayloadV2) IncidentEngineParamBinding {
+	var arrayValue []IncidentEngineParamBindingValue
+	if pb.ArrayValue != nil {
+		for _, v := range *pb.ArrayValue {
+			arrayValue = append(arrayValue, IncidentEngineParamBindingValue{
+				Literal:   types.StringPointerValue(v.Literal),
+				Reference: types.StringPointerValue(v.Reference),
+			})
+		}
+	}
+
+	var value *IncidentEngineParamBindingValue
+	if pb.Value != nil {
+		value = &IncidentEngineParamBindingValue{
+			Literal:   types.StringPointerValue(pb.Value.Literal),
+			Reference: types.StringPointerValue(pb.Value.Reference),
+		}
+	}
+
+	return IncidentEngineParamBinding{
+		ArrayValue: arrayValue,
+		Value:      value,
+	}
+}
+
+func fromPayloadExpressions(expressions []client.ExpressionPayloadV2) IncidentEngineExpressions {
+	out := IncidentEngineExpressions{}
+
+	for _, e := range expressions {
+		expression := IncidentEngineExpression{
+			Label:         types.StringValue(e.Label),
+			Operations:    fromPayloadOperations(e.Operations),
+			Reference:     types.StringValue(e.Reference),
+			RootReference: types.StringValue(e.RootReference),
+		}
+		if e.ElseBranch != nil {
+			expression.ElseBranch = &IncidentEngineElseBranch{
+				Result: fromPayloadParamBinding(e.ElseBranch.Result),
+			}
+		}
+		out = append(out, expression)
+	}
+
+	return out
+}
+
+func fromPayloadOperations(operations []client.ExpressionOperationPayloadV2) []IncidentEngineExpressionOperation {
+	out := []IncidentEngineExpressionOperation{}
+
+	for _, o := range operations {
+		operation := IncidentEngineExpressionOperation{
+			OperationType: types.StringValue(string(o.OperationType)),
+		}
+		if o.Branches != nil {
+			operation.Branches = &IncidentEngineExpressionBranchesOpts{
+				Branches: fromPayloadBranches(o.Branches.Branches),
+				Returns:  fromPayloadReturns(o.Branches.Returns),
+			}
+		}
+		if o.Filter != nil {
+			operation.Filter = &IncidentEngineExpressionFilterOpts{
+				ConditionGroups: fromPayloadConditionGroups(o.Filter.ConditionGroups),
+			}
+		}
+		if o.Navigate != nil {
+			operation.Navigate = &IncidentEngineExpressionNavigateOpts{
+				Reference: types.StringValue(o.Navigate.Reference),
+			}
+		}
+		if o.Parse != nil {
+			operation.Parse = &IncidentEngineExpressionParseOpts{
+				Returns: fromPayloadReturns(o.Parse.Returns),
+				Source:  types.StringValue(o.Parse.Source),
+			}
+		}
+		out = append(out, operation)
+	}
+
+	return out
+}
+
+func fromPayloadBranches(branches []client.ExpressionBranchPayloadV2) []IncidentEngineBranch {
+	out := []IncidentEngineBranch{}
+
+	for _, b := range branches {
+		out = append(out, IncidentEngineBranch{
+			ConditionGroups: fromPayloadConditionGroups(b.ConditionGroups),
+			Result:          fromPayloadParamBinding(b.Result),
+		})
+	}
+
+	return out
+}
+
+func fromPayloadReturns(returns client.ReturnsMetaV2) IncidentEngineReturnsMeta {
+	return IncidentEngineReturnsMeta{
+		Array: types.BoolValue(returns.Array),
+		Type:  types.StringValue(returns.Type),
+	}
+}